@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type (
+	// Aggregate groups several independent errors into a single error value.
+	// Each member keeps its own chain and call stack, and Is/As look into every member.
+	Aggregate struct {
+		errs []error
+	}
+)
+
+// Append combines err with the additional errors into an Aggregate, skipping nil values.
+// Aggregates passed in are flattened, so appending repeatedly never nests them.
+//
+// Parameters:
+//   - err: the initial error; may be nil
+//   - errs: additional errors to append; nil values are ignored
+//
+// Returns:
+//   - error: nil if every input is nil, the single non-nil error if only one is present, or an *Aggregate otherwise
+func Append(err error, errs ...error) error {
+	members := make([]error, 0, len(errs)+1)
+
+	for _, e := range append([]error{err}, errs...) {
+		switch x := e.(type) { //nolint:errorlint
+		case nil:
+		case *Aggregate:
+			members = append(members, x.errs...)
+		default:
+			members = append(members, e)
+		}
+	}
+
+	switch len(members) {
+	case 0:
+		return nil
+	case 1:
+		return members[0]
+	default:
+		return &Aggregate{errs: members}
+	}
+}
+
+// AppendDeferred joins err into the error pointed to by target, turning it into an Aggregate when both are set.
+// It is designed for named return values in deferred cleanup, so Close errors are never silently dropped:
+//
+//	defer func() { errors.AppendDeferred(&err, f.Close()) }()
+//
+// Parameters:
+//   - target: a pointer to the error to extend; must not be nil
+//   - err: the cleanup error to join; if nil, target is left untouched
+func AppendDeferred(target *error, err error) {
+	if err == nil {
+		return
+	}
+
+	*target = Append(*target, err)
+}
+
+// AppendDeferredFunc calls fn and joins its error into the error pointed to by target.
+// Unlike AppendDeferred it can be deferred directly, because fn is only invoked when the deferred call runs:
+//
+//	defer errors.AppendDeferredFunc(&err, f.Close)
+//
+// Parameters:
+//   - target: a pointer to the error to extend; must not be nil
+//   - fn: the cleanup function to invoke
+func AppendDeferredFunc(target *error, fn func() error) {
+	AppendDeferred(target, fn())
+}
+
+// Error returns the messages of all members, one per line.
+//
+// Returns:
+//   - string: the combined error message
+func (a *Aggregate) Error() string {
+	messages := make([]string, 0, len(a.errs))
+	for _, err := range a.errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the members of the aggregate, enabling Is/As to inspect each of them.
+func (a *Aggregate) Unwrap() []error {
+	return a.errs
+}
+
+// Errors returns a copy of the members of the aggregate.
+//
+// Returns:
+//   - []error: the aggregated errors in the order they were appended
+func (a *Aggregate) Errors() []error {
+	return append([]error(nil), a.errs...)
+}
+
+// Len returns the number of errors in the aggregate.
+func (a *Aggregate) Len() int {
+	return len(a.errs)
+}
+
+// MarshalJSON serializes the aggregate with its combined message and every member.
+//
+// Returns:
+//   - []byte: the JSON encoding of the aggregate
+//   - error: an error if the encoding fails
+func (a *Aggregate) MarshalJSON() ([]byte, error) {
+	out := toJSONError(a)
+	out.Version = WireVersion
+
+	return json.Marshal(out)
+}
@@ -0,0 +1,184 @@
+// Package analyzer provides a go/analysis Analyzer enforcing consistent error wrapping hygiene
+// with github.com/ceearrashee/errors.
+//
+// It reports:
+//   - errors returned as-is right after being received from a call into another package,
+//   - errors.New calls inside loops, which allocate a new error on every iteration,
+//   - fmt.Errorf calls using %w, where errors.Wrapf keeps a call stack.
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const packagePath = "github.com/ceearrashee/errors"
+
+// Analyzer is the wrap hygiene analyzer, runnable via `go vet -vettool` with cmd/wraplint.
+var Analyzer = &analysis.Analyzer{ //nolint:gochecknoglobals
+	Name:     "wraplint",
+	Doc:      "reports unwrapped errors crossing package boundaries, errors.New in loops and fmt.Errorf with %w",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	inspect.WithStack([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node, push bool, _ []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var body *ast.BlockStmt
+
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+
+		if body != nil {
+			checkExternalReturns(pass, body)
+		}
+
+		return true
+	})
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call := n.(*ast.CallExpr) //nolint:forcetypeassert
+
+		fn := calledFunc(pass, call)
+		if fn == nil || fn.Pkg() == nil {
+			return true
+		}
+
+		switch {
+		case fn.Name() == "New" && (fn.Pkg().Path() == "errors" || fn.Pkg().Path() == packagePath) && inLoop(stack):
+			pass.Reportf(call.Pos(), "errors.New called inside a loop; declare a package-level sentinel instead")
+		case fn.Name() == "Errorf" && fn.Pkg().Path() == "fmt" && usesWrapVerb(pass, call):
+			pass.Reportf(call.Pos(), "fmt.Errorf with %%w drops the call stack; use errors.Wrapf instead")
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+// checkExternalReturns reports return statements that pass along an error variable assigned
+// from a call into another package without wrapping it.
+func checkExternalReturns(pass *analysis.Pass, body *ast.BlockStmt) {
+	external := make(map[types.Object]string)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			recordExternalAssign(pass, stmt, external)
+		case *ast.ReturnStmt:
+			for _, result := range stmt.Results {
+				ident, ok := result.(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				if pkg, found := external[pass.TypesInfo.ObjectOf(ident)]; found {
+					pass.Reportf(result.Pos(), "error from package %s returned without wrapping; use errors.Wrap to add context", pkg)
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+func recordExternalAssign(pass *analysis.Pass, stmt *ast.AssignStmt, external map[types.Object]string) {
+	if len(stmt.Rhs) != 1 {
+		return
+	}
+
+	call, ok := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	fn := calledFunc(pass, call)
+
+	for _, lhs := range stmt.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil || !isErrorType(obj.Type()) {
+			continue
+		}
+
+		if fn != nil && fn.Pkg() != nil && fn.Pkg() != pass.Pkg && fn.Pkg().Path() != packagePath {
+			external[obj] = fn.Pkg().Path()
+		} else {
+			delete(external, obj)
+		}
+	}
+}
+
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := pass.TypesInfo.Uses[ident].(*types.Func)
+
+	return fn
+}
+
+func inLoop(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		case *ast.FuncDecl, *ast.FuncLit:
+			return false
+		}
+	}
+
+	return false
+}
+
+func usesWrapVerb(pass *analysis.Pass, call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return false
+	}
+
+	return strings.Contains(constant.StringVal(tv.Value), "%w")
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"maps"
+	"slices"
+)
+
+// annotate attaches metadata to err without altering its message.
+// When err is an *Error wrapping another error it is shallow-copied so the original value stays untouched;
+// sentinels and other errors are wrapped into a new description-less *Error, see (*Error).annotated.
+//
+// Parameters:
+//   - err: the error to annotate; if nil, nil is returned
+//   - apply: the function setting metadata on the resulting *Error
+//
+// Returns:
+//   - error: the annotated error, or nil if err is nil
+func annotate(err error, apply func(*Error)) error {
+	if err == nil {
+		return nil
+	}
+
+	var annotated *Error
+
+	if frameworkErr, ok := err.(*Error); ok { //nolint:errorlint
+		annotated = frameworkErr.annotated()
+	} else {
+		annotated = &Error{error: err}
+	}
+
+	apply(annotated)
+
+	return annotated
+}
+
+// annotated returns the *Error receiving the metadata attached to e. Leaf errors, which include package-level
+// sentinels such as ErrNotFound, and registered definitions are compared by identity, so they are wrapped into
+// a description-less *Error keeping Is, DefinitionOf and the policies keyed on them working; errors wrapping
+// another error are created per call and are cloned instead, so the metadata applies to their own level.
+func (e *Error) annotated() *Error {
+	if e.error == nil || isRegisteredSentinel()(e) {
+		return &Error{error: e}
+	}
+
+	return e.clone()
+}
+
+// clone returns a copy of e that can be modified without affecting e.
+func (e *Error) clone() *Error {
+	clone := *e
+	clone.causes = maps.Clone(e.causes)
+	clone.fields = maps.Clone(e.fields)
+	clone.attachments = slices.Clone(e.attachments)
+	clone.tags = slices.Clone(e.tags)
+
+	return &clone
+}
@@ -0,0 +1,75 @@
+package errors_test
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestAnnotateKeepsSentinelIdentity(t *testing.T) {
+	t.Parallel()
+
+	custom := errors.New("custom sentinel")
+	leaf := errors.Newf("leaf")
+
+	cases := map[string]struct {
+		err      error
+		sentinel error
+	}{
+		"predefined sentinel": {
+			err:      errors.Annotate(errors.ErrNotFound, errors.WithField("k", 1)),
+			sentinel: errors.ErrNotFound,
+		},
+		"custom sentinel": {
+			err:      errors.Annotate(custom, errors.WithTags("team:core")),
+			sentinel: custom,
+		},
+		"With on a leaf": {
+			err:      leaf.WithField("k", 1),
+			sentinel: leaf,
+		},
+		"wrapped sentinel": {
+			err:      errors.Annotate(errors.Wrap(errors.ErrNotFound, "load user"), errors.WithField("k", 1)),
+			sentinel: errors.ErrNotFound,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if !errors.Is(tc.err, tc.sentinel) {
+				t.Fatalf("Is(%v, %v) = false, want true", tc.err, tc.sentinel)
+			}
+
+			if errors.FieldsOf(tc.err) == nil && errors.TagsOf(tc.err) == nil {
+				t.Fatalf("metadata of %v was lost", tc.err)
+			}
+		})
+	}
+}
+
+func TestAnnotateKeepsClassification(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Annotate(errors.ErrNotFound, errors.WithField("k", 1))
+
+	def, ok := errors.DefinitionOf(err)
+	if !ok || def.Err != errors.ErrNotFound { //nolint:errorlint
+		t.Fatalf("DefinitionOf = %v, %v, want the ErrNotFound definition", def.Code, ok)
+	}
+
+	if status := errors.HTTPStatusOf(err); status != http.StatusNotFound {
+		t.Fatalf("HTTPStatusOf = %d, want %d", status, http.StatusNotFound)
+	}
+
+	if level := errors.LogLevelOf(err); level != slog.LevelWarn {
+		t.Fatalf("LogLevelOf = %v, want %v", level, slog.LevelWarn)
+	}
+
+	if got, want := err.Error(), errors.ErrNotFound.Error(); got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"slices"
+)
+
+type (
+	// Attachment is a named binary or textual blob carried by an error, such as the failing payload or a diff.
+	Attachment struct {
+		Name        string `json:"name"`
+		ContentType string `json:"content_type,omitempty"`
+		Data        []byte `json:"data"`
+	}
+)
+
+// WithAttachment attaches a named blob to the error. Attachments are serialized with the error
+// and handed to reporters as attachments rather than tags.
+//
+// Parameters:
+//   - name: the name of the attachment, e.g. "payload.json"; an attachment with the same name is replaced
+//   - data: the content of the attachment
+//   - contentType: the media type of the content, e.g. "application/json"
+//
+// Returns:
+//   - Option: the option attaching the blob
+func WithAttachment(name string, data []byte, contentType string) Option {
+	return func(e *Error) {
+		e.attachments = slices.DeleteFunc(e.attachments, func(a Attachment) bool { return a.Name == name })
+		e.attachments = append(e.attachments, Attachment{Name: name, ContentType: contentType, Data: data})
+	}
+}
+
+// AttachmentsOf collects the attachments carried anywhere in the chain.
+// When the same name is used at several levels, the outermost attachment wins.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - []Attachment: the attachments, outermost first, or nil if there are none
+func AttachmentsOf(err error) []Attachment {
+	var attachments []Attachment
+
+	for e := range Chain(err) {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
+		if !ok {
+			continue
+		}
+
+		for _, attachment := range frameworkErr.attachments {
+			if !slices.ContainsFunc(attachments, func(a Attachment) bool { return a.Name == attachment.Name }) {
+				attachments = append(attachments, attachment)
+			}
+		}
+	}
+
+	return attachments
+}
+
+// AttachmentOf returns the attachment with the given name carried anywhere in the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//   - name: the name of the attachment
+//
+// Returns:
+//   - Attachment: the outermost attachment with that name
+//   - bool: true if the attachment was found
+func AttachmentOf(err error, name string) (Attachment, bool) {
+	for _, attachment := range AttachmentsOf(err) {
+		if attachment.Name == name {
+			return attachment, true
+		}
+	}
+
+	return Attachment{}, false
+}
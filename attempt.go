@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// FieldAttempt is the field holding the attempt number set by WithAttempt.
+	FieldAttempt = "attempt"
+	// FieldDuration is the field holding the duration set by WithDuration.
+	FieldDuration = "duration"
+)
+
+// WithAttempt records which attempt of a retried operation failed. Retry helpers and clients set it
+// so the error alone tells how many tries were made.
+//
+// Parameters:
+//   - n: the attempt number, starting at 1
+//
+// Returns:
+//   - Option: the option recording the attempt in the FieldAttempt field
+func WithAttempt(n int) Option {
+	return WithField(FieldAttempt, n)
+}
+
+// WithDuration records how long the failing operation took.
+//
+// Parameters:
+//   - d: the duration of the operation
+//
+// Returns:
+//   - Option: the option recording the duration in the FieldDuration field
+func WithDuration(d time.Duration) Option {
+	return WithField(FieldDuration, d)
+}
+
+// AttemptOf returns the attempt number recorded with WithAttempt by the outermost error of the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - int: the attempt number
+//   - bool: true if an attempt was recorded
+func AttemptOf(err error) (int, bool) {
+	attempt, ok := FieldsOf(err)[FieldAttempt].(int)
+
+	return attempt, ok
+}
+
+// DurationOf returns the duration recorded with WithDuration by the outermost error of the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - time.Duration: the duration of the failing operation
+//   - bool: true if a duration was recorded
+func DurationOf(err error) (time.Duration, bool) {
+	duration, ok := FieldsOf(err)[FieldDuration].(time.Duration)
+
+	return duration, ok
+}
+
+// attemptSummary renders the attempt and duration of err for %+v, e.g. " (attempt 3, took 1.2s)".
+func attemptSummary(err error) string {
+	attempt, hasAttempt := AttemptOf(err)
+	duration, hasDuration := DurationOf(err)
+
+	switch {
+	case hasAttempt && hasDuration:
+		return fmt.Sprintf(" (attempt %d, took %s)", attempt, duration)
+	case hasAttempt:
+		return fmt.Sprintf(" (attempt %d)", attempt)
+	case hasDuration:
+		return fmt.Sprintf(" (took %s)", duration)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+const (
+	autoCodeLength = 6
+
+	// FieldReferenceCode is the field, and the key of the metadata written by the integration packages, holding
+	// the reference code (see AutoCode) an error was reported with by another service.
+	FieldReferenceCode = "reference_code"
+)
+
+// AutoCode derives a short, stable reference code for the error from its template (or description,
+// with interpolated values ignored) and the function that created it. The same failure at the same site
+// always yields the same code, so it can be shown to users ("reference code E4F2A1") and searched for in
+// logs and telemetry.
+// The code is prefixed with the outermost code prefix of the chain (see WithCodePrefix), e.g. "BILL-E4F2A1".
+//
+// Parameters:
+//   - err: the error to derive the code from
+//
+// Returns:
+//   - string: a six-character uppercase hexadecimal code after the code prefix, or an empty string if err is nil
+func AutoCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	message := TemplateOf(err)
+	site := ""
+
+	if origin := FindOriginalErrorWithStack(err); origin != nil {
+		site = origin.creationSite()
+
+		if message == "" {
+			message, _ = extractValues(origin.Description)
+		}
+	}
+
+	if message == "" {
+		message, _ = extractValues(stableMessage(err))
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(message + "\x00" + site)) //nolint:errcheck,revive
+
+	return codePrefixOf(err) + fmt.Sprintf("%08X", hash.Sum32())[:autoCodeLength]
+}
+
+// creationSite returns the function name of the topmost application frame of the error's call stack
+// (see FrameOrigin), or of its topmost frame if no frame belongs to the application.
+func (e *Error) creationSite() string {
+	if e.stack == nil || len(*e.stack) == 0 {
+		return ""
+	}
+
+	frames := e.stack.Frames()
+	for _, frame := range frames {
+		if frame.Origin == OriginApp {
+			return frame.Function
+		}
+	}
+
+	if len(frames) == 0 {
+		return ""
+	}
+
+	return frames[0].Function
+}
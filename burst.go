@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultBurstWindow is the window over which the burst detector counts the occurrences of a fingerprint.
+	DefaultBurstWindow = time.Minute
+	// DefaultBurstFactor is the rate increase, relative to the baseline of a fingerprint, flagged as a burst.
+	DefaultBurstFactor = 10
+	// DefaultBurstMinCount is the number of occurrences in a window below which no burst is flagged.
+	DefaultBurstMinCount = 10
+
+	// burstBaselineWeight is the weight of the latest completed window in the baseline of a fingerprint.
+	burstBaselineWeight = 0.5
+)
+
+type (
+	// BurstConfig configures the detector flagging the fingerprints whose rate jumps far above their baseline,
+	// so alerts can key on bursts rather than absolute counts.
+	BurstConfig struct {
+		// Window is the duration over which occurrences are counted.
+		Window time.Duration
+		// Factor is the ratio between the count of the current window and the baseline (a moving average of the
+		// previous windows, at least 1) above which the fingerprint is bursting.
+		Factor float64
+		// MinCount is the number of occurrences the current window must reach to be flagged.
+		MinCount int64
+	}
+
+	// burstState tracks the rate of a single fingerprint.
+	burstState struct {
+		windowStart time.Time
+		current     int64
+		baseline    float64
+		burstUntil  time.Time
+	}
+
+	burstDetector struct {
+		mu     sync.Mutex
+		states map[string]*burstState
+	}
+)
+
+var (
+	burstConfig atomic.Pointer[BurstConfig]                            //nolint:gochecknoglobals
+	bursts      = &burstDetector{states: make(map[string]*burstState)} //nolint:gochecknoglobals
+)
+
+// DefaultBurstConfig returns the configuration used until SetBurstConfig is called: a fingerprint bursts when it
+// occurs at least DefaultBurstMinCount times in a minute and ten times more often than its baseline.
+//
+// Returns:
+//   - BurstConfig: the default configuration
+func DefaultBurstConfig() BurstConfig {
+	return BurstConfig{Window: DefaultBurstWindow, Factor: DefaultBurstFactor, MinCount: DefaultBurstMinCount}
+}
+
+// SetBurstConfig replaces the configuration of the burst detector fed by Observe. The rates tracked so far are kept.
+//
+// Parameters:
+//   - cfg: the configuration to apply
+func SetBurstConfig(cfg BurstConfig) {
+	burstConfig.Store(&cfg)
+}
+
+// IsBursting reports whether the fingerprint of err (see AutoCode) is bursting: its rate, as recorded by Observe,
+// jumped by the configured factor over its baseline in the current or the previous window. Reporters call it
+// after Observe to tag reports, e.g. with error.burst=true.
+//
+// Parameters:
+//   - err: the reported error
+//
+// Returns:
+//   - bool: true if the fingerprint is bursting, false otherwise or if err is nil
+func IsBursting(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return bursts.bursting(AutoCode(err), now())
+}
+
+func currentBurstConfig() BurstConfig {
+	if cfg := burstConfig.Load(); cfg != nil {
+		return *cfg
+	}
+
+	return DefaultBurstConfig()
+}
+
+// observe counts an occurrence of the fingerprint at t, flagging a burst when the rate jumps over the baseline.
+func (d *burstDetector) observe(fingerprint string, t time.Time) {
+	cfg := currentBurstConfig()
+	if cfg.Window <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.states[fingerprint]
+	if !ok {
+		if len(d.states) >= maxTrackedFingerprints {
+			return
+		}
+
+		state = &burstState{windowStart: t}
+		d.states[fingerprint] = state
+	}
+
+	state.roll(t, cfg.Window)
+	state.current++
+
+	if state.current >= cfg.MinCount && float64(state.current) >= cfg.Factor*max(state.baseline, 1) {
+		state.burstUntil = state.windowStart.Add(2 * cfg.Window) //nolint:mnd
+	}
+}
+
+// bursting reports whether the fingerprint was flagged at t.
+func (d *burstDetector) bursting(fingerprint string, t time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.states[fingerprint]
+
+	return ok && t.Before(state.burstUntil)
+}
+
+// snapshot returns the fingerprints bursting at t, sorted.
+func (d *burstDetector) snapshot(t time.Time) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var bursting []string
+
+	for fingerprint, state := range d.states {
+		if t.Before(state.burstUntil) {
+			bursting = append(bursting, fingerprint)
+		}
+	}
+
+	slices.Sort(bursting)
+
+	return bursting
+}
+
+func (d *burstDetector) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.states)
+}
+
+// roll closes the windows elapsed before t, folding their counts into the baseline.
+func (s *burstState) roll(t time.Time, window time.Duration) {
+	for !t.Before(s.windowStart.Add(window)) {
+		s.baseline = burstBaselineWeight*float64(s.current) + (1-burstBaselineWeight)*s.baseline
+		s.current = 0
+		s.windowStart = s.windowStart.Add(window)
+
+		// Past a long quiet period the baseline has decayed to nothing: skip the empty windows at once.
+		if s.baseline < 1 && !t.Before(s.windowStart.Add(window)) {
+			s.baseline = 0
+			s.windowStart = t
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+var callersFunc atomic.Pointer[func(skip int) *Stack] //nolint:gochecknoglobals
+
+// SetCallersFunc replaces the function capturing call stacks for every error created or wrapped by this package.
+// It is a test hook: tests and fuzzers inject synthetic stacks to exercise formatting, serialization
+// and deduplication deterministically, without depending on real runtime frames.
+//
+// Parameters:
+//   - fn: the capture function, receiving the number of extra frames the caller asked to skip
+//     (as passed to NewSkipping/WrapSkipping, 0 otherwise); nil restores the runtime capture
+func SetCallersFunc(fn func(skip int) *Stack) {
+	if fn == nil {
+		callersFunc.Store(nil)
+
+		return
+	}
+
+	callersFunc.Store(&fn)
+}
@@ -0,0 +1,175 @@
+package errors
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// canonicalIndent indents the nested sections of a canonical rendering.
+	canonicalIndent = "  "
+	// canonicalAddress replaces memory addresses in a canonical rendering.
+	canonicalAddress = "0x?"
+)
+
+type (
+	// CanonicalOption customizes the rendering of Canonicalize.
+	CanonicalOption func(*canonicalConfig)
+
+	canonicalConfig struct {
+		lineNumbers bool
+		root        string
+	}
+)
+
+// addressPattern matches hexadecimal memory addresses, such as those printed for pointers.
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]+`) //nolint:gochecknoglobals
+
+// CanonicalLineNumbers controls whether the frames rendered by Canonicalize include their line number.
+//
+// Parameters:
+//   - include: true to render line numbers, false to omit them (the default) so golden files survive unrelated edits
+//
+// Returns:
+//   - CanonicalOption: the option applying the line number rendering
+func CanonicalLineNumbers(include bool) CanonicalOption {
+	return func(c *canonicalConfig) {
+		c.lineNumbers = include
+	}
+}
+
+// CanonicalRoot sets the directory the file paths of frames are rendered relative to.
+//
+// Parameters:
+//   - root: the root directory (the working directory by default, the package directory under go test)
+//
+// Returns:
+//   - CanonicalOption: the option applying the root directory
+func CanonicalRoot(root string) CanonicalOption {
+	return func(c *canonicalConfig) {
+		c.root = root
+	}
+}
+
+// Canonicalize renders err as a stable, comparable snapshot for golden-file tests: the chain message, code,
+// fields and tags in sorted order, named causes, the members of aggregates, and the application frames of the stack
+// with relative file paths. Memory addresses are replaced by "0x?" and fields are scrubbed; instance IDs,
+// reference codes and frames of the standard library and dependencies are left out, since they vary between runs.
+//
+// Parameters:
+//   - err: the error to render
+//   - opts: options customizing the rendering
+//
+// Returns:
+//   - string: the canonical rendering, or an empty string if err is nil
+func Canonicalize(err error, opts ...CanonicalOption) string {
+	if err == nil {
+		return ""
+	}
+
+	cfg := canonicalConfig{}
+	if wd, wdErr := os.Getwd(); wdErr == nil {
+		cfg.root = wd
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var b strings.Builder
+
+	canonicalize(&b, err, "", cfg)
+
+	return b.String()
+}
+
+func canonicalize(b *strings.Builder, err error, indent string, cfg canonicalConfig) {
+	line := func(format string, args ...any) {
+		b.WriteString(indent)
+		_, _ = fmt.Fprintf(b, format, args...) //nolint:errcheck
+		b.WriteByte('\n')
+	}
+
+	line("message: %s", canonicalText(ScrubMessage(err.Error())))
+
+	if aggregate, ok := err.(*Aggregate); ok { //nolint:errorlint
+		line("errors:")
+
+		for i, member := range aggregate.errs {
+			line("%s[%d]:", canonicalIndent, i)
+			canonicalize(b, member, indent+canonicalIndent+canonicalIndent, cfg)
+		}
+
+		return
+	}
+
+	if code := CodeOf(err); code != "" {
+		line("code: %s", code)
+	}
+
+	if fields := ScrubFields(FieldsOf(err)); len(fields) > 0 {
+		line("fields:")
+
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			line("%s%s: %s", canonicalIndent, key, canonicalText(fmt.Sprint(fields[key])))
+		}
+	}
+
+	if tags := slices.Sorted(slices.Values(TagsOf(err))); len(tags) > 0 {
+		line("tags: %s", strings.Join(tags, ", "))
+	}
+
+	if causes := NamedCauses(err); len(causes) > 0 {
+		line("causes:")
+
+		for _, name := range slices.Sorted(maps.Keys(causes)) {
+			line("%s%s:", canonicalIndent, name)
+			canonicalize(b, causes[name], indent+canonicalIndent+canonicalIndent, cfg)
+		}
+	}
+
+	var frames []string
+
+	for _, frame := range FramesOf(err) {
+		if frame.Origin != OriginApp {
+			continue
+		}
+
+		location := canonicalPath(frame.File, cfg.root)
+		if cfg.lineNumbers {
+			location += ":" + strconv.Itoa(frame.Line)
+		}
+
+		frames = append(frames, frame.Function+" "+location)
+	}
+
+	if len(frames) > 0 {
+		line("stack:")
+
+		for _, frame := range frames {
+			line("%s%s", canonicalIndent, frame)
+		}
+	}
+}
+
+// canonicalText replaces memory addresses and keeps multi-line messages on a single line.
+func canonicalText(s string) string {
+	return strings.ReplaceAll(addressPattern.ReplaceAllString(s, canonicalAddress), "\n", `\n`)
+}
+
+// canonicalPath renders a file path relative to root, or its base name when it lies outside root.
+func canonicalPath(file, root string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.Base(file)
+}
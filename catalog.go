@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// catalogColumns are the columns of the CSV export of the catalog.
+//
+//nolint:gochecknoglobals
+var catalogColumns = []string{
+	"code", "message", "template", "http_status", "grpc_code", "retryable", "expected", "owner", "message_key",
+}
+
+// Catalog returns every registered definition, sorted by code, with its gRPC code resolved by GRPCCodeOf when not
+// set explicitly, for generating runbooks and dashboards from the live registry.
+//
+// Returns:
+//   - []ErrorDefinition: the registered definitions
+func Catalog() []ErrorDefinition {
+	defs := Definitions()
+
+	for i := range defs {
+		defs[i].GRPCCode = GRPCCodeOf(defs[i])
+	}
+
+	return defs
+}
+
+// WriteCatalogJSON writes the catalog as a JSON array.
+//
+// Parameters:
+//   - w: the writer to write to
+//
+// Returns:
+//   - error: an error if encoding or writing fails
+func WriteCatalogJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(Catalog()); err != nil {
+		return Wrap(err, "write catalog JSON")
+	}
+
+	return nil
+}
+
+// WriteCatalogCSV writes the catalog as CSV with a header row.
+//
+// Parameters:
+//   - w: the writer to write to
+//
+// Returns:
+//   - error: an error if writing fails
+func WriteCatalogCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write(catalogColumns) //nolint:errcheck // reported by Error below
+
+	for _, def := range Catalog() {
+		_ = writer.Write([]string{ //nolint:errcheck // reported by Error below
+			string(def.Code),
+			def.Message,
+			def.Template,
+			strconv.Itoa(def.HTTPStatus),
+			def.GRPCCode,
+			strconv.FormatBool(def.Retryable),
+			strconv.FormatBool(def.Expected),
+			def.Owner,
+			def.MessageKey,
+		})
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return Wrap(err, "write catalog CSV")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"iter"
+)
+
+// Chain returns an iterator over every error in the chain of err, starting with err itself.
+// The chain is traversed depth-first, following both Unwrap() error and Unwrap() []error,
+// in the same order used by Is and As.
+//
+// Parameters:
+//   - err: the root error to traverse
+//
+// Returns:
+//   - iter.Seq[error]: an iterator yielding each error in the chain
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		walkChain(err, yield)
+	}
+}
+
+func walkChain(err error, yield func(error) bool) bool {
+	for err != nil {
+		if !yield(err) {
+			return false
+		}
+
+		switch x := err.(type) { //nolint:errorlint
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range x.Unwrap() {
+				if !walkChain(child, yield) {
+					return false
+				}
+			}
+
+			return true
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// First returns the first error in the chain that satisfies the predicate.
+//
+// Parameters:
+//   - err: the root error to search through
+//   - pred: the predicate each error in the chain is tested against
+//
+// Returns:
+//   - error: the first matching error, or nil if none match
+func First(err error, pred func(error) bool) error {
+	for e := range Chain(err) {
+		if pred(e) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Last returns the last error in the chain that satisfies the predicate.
+//
+// Parameters:
+//   - err: the root error to search through
+//   - pred: the predicate each error in the chain is tested against
+//
+// Returns:
+//   - error: the last matching error, or nil if none match
+func Last(err error, pred func(error) bool) error {
+	var last error
+
+	for e := range Chain(err) {
+		if pred(e) {
+			last = e
+		}
+	}
+
+	return last
+}
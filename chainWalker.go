@@ -0,0 +1,106 @@
+package errors
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"strings"
+)
+
+// chainWalker rebuilds an error chain level by level, for the conversions (Strip, Shrink, Compact) that must reach
+// every *Error of the chain, including those below wrappers of other packages such as fmt.Errorf and errors.Join.
+type chainWalker struct {
+	// isSentinel reports the registered sentinels, which are kept unchanged so Is keeps matching them.
+	isSentinel func(*Error) bool
+	// level converts one *Error; walk converts the errors it wraps and reports whether they changed.
+	level func(e *Error, walk func(error) (error, bool)) error
+	// join builds the conversion of an aggregate from its converted members.
+	join func(members ...error) error
+}
+
+// rebuild converts err, returning it unchanged when nothing in its chain had to be converted.
+func (w *chainWalker) rebuild(err error) error {
+	rebuilt, _ := w.walk(err)
+
+	return rebuilt
+}
+
+// walk converts err and reports whether the result differs from err. Errors of other packages are kept when
+// nothing below them changed; otherwise they are rebuilt around the converted errors with their message kept.
+func (w *chainWalker) walk(err error) (error, bool) {
+	switch x := err.(type) { //nolint:errorlint
+	case nil:
+		return nil, false
+	case *Aggregate:
+		members := make([]error, 0, len(x.errs))
+		for _, member := range x.errs {
+			members = append(members, w.rebuild(member))
+		}
+
+		return w.join(members...), true
+	case *Error:
+		if w.isSentinel(x) {
+			return x, false
+		}
+
+		rebuilt := w.level(x, w.walk)
+
+		return rebuilt, rebuilt != error(x) //nolint:errorlint
+	case interface{ Unwrap() error }:
+		inner := x.Unwrap()
+
+		rebuilt, changed := w.walk(inner)
+		if !changed {
+			return err, false
+		}
+
+		if prefix, ok := strings.CutSuffix(err.Error(), inner.Error()); ok {
+			return fmt.Errorf("%s%w", prefix, rebuilt), true
+		}
+
+		return &rebuiltWrapper{msg: err.Error(), errs: []error{rebuilt}}, true
+	case interface{ Unwrap() []error }:
+		members := x.Unwrap()
+		rebuilt := make([]error, 0, len(members))
+		messages := make([]string, 0, len(members))
+		changed := false
+
+		for _, member := range members {
+			converted, memberChanged := w.walk(member)
+			rebuilt = append(rebuilt, converted)
+			changed = changed || memberChanged
+
+			if member != nil {
+				messages = append(messages, member.Error())
+			}
+		}
+
+		if !changed {
+			return err, false
+		}
+
+		if err.Error() == strings.Join(messages, "\n") {
+			return stdErrors.Join(rebuilt...), true
+		}
+
+		return &rebuiltWrapper{msg: err.Error(), errs: rebuilt}, true
+	default:
+		return err, false
+	}
+}
+
+// rebuiltWrapper stands in for a wrapper of another package whose message does not follow the fmt.Errorf or
+// errors.Join layout, keeping its message around the converted errors.
+type rebuiltWrapper struct {
+	msg  string
+	errs []error
+}
+
+// Error returns the message of the original wrapper.
+func (w *rebuiltWrapper) Error() string {
+	return w.msg
+}
+
+// Unwrap returns the converted errors, so Is and As keep matching them.
+func (w *rebuiltWrapper) Unwrap() []error {
+	return w.errs
+}
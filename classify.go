@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+)
+
+// ClassificationRule maps errors matching a predicate onto a predefined (or registered) sentinel.
+type ClassificationRule struct {
+	// Match reports whether the rule applies to the error.
+	Match func(err error) bool
+	// Err is the sentinel the matching errors are classified with.
+	Err error
+}
+
+var (
+	classificationMu    sync.RWMutex         //nolint:gochecknoglobals
+	classificationRules []ClassificationRule //nolint:gochecknoglobals
+)
+
+// builtinClassificationRules map standard library errors onto the predefined taxonomy.
+// They apply after the registered rules.
+//
+//nolint:gochecknoglobals
+var builtinClassificationRules = []ClassificationRule{
+	{Match: isSentinel(context.Canceled), Err: ErrCanceled},
+	{Match: isSentinel(context.DeadlineExceeded, os.ErrDeadlineExceeded), Err: ErrTimeout},
+	{Match: isNetTimeout, Err: ErrTimeout},
+	{Match: isSentinel(os.ErrNotExist), Err: ErrNotFound},
+	{Match: isSentinel(os.ErrPermission), Err: ErrForbiddenAction},
+	{Match: isSentinel(os.ErrExist), Err: ErrConflict},
+}
+
+// TruncatedReadRule returns the opt-in rule classifying io.EOF and io.ErrUnexpectedEOF as ErrBadRequest, for
+// services whose truncated reads come from client request bodies rather than from upstreams or database connections:
+//
+//	errors.RegisterClassificationRule(errors.TruncatedReadRule())
+//
+// Returns:
+//   - ClassificationRule: the rule mapping truncated reads onto ErrBadRequest
+func TruncatedReadRule() ClassificationRule {
+	return ClassificationRule{Match: isSentinel(io.EOF, io.ErrUnexpectedEOF), Err: ErrBadRequest}
+}
+
+// RegisterClassificationRule adds a rule consulted by Classify before the built-in rules,
+// e.g. to map a driver's unique violation onto ErrConflict. Rules apply in registration order.
+//
+// Parameters:
+//   - rule: the rule to add; rules without Match or Err are ignored
+func RegisterClassificationRule(rule ClassificationRule) {
+	if rule.Match == nil || rule.Err == nil {
+		reportMisuse("RegisterClassificationRule called without Match or Err")
+
+		return
+	}
+
+	classificationMu.Lock()
+	defer classificationMu.Unlock()
+
+	classificationRules = append(classificationRules, rule)
+}
+
+// Classify maps well-known errors onto the predefined taxonomy: context.Canceled becomes ErrCanceled,
+// deadlines and network timeouts become ErrTimeout, os.ErrNotExist and os.ErrPermission become ErrNotFound
+// and ErrForbiddenAction, and rules registered with RegisterClassificationRule apply first. The original error
+// stays in the chain. io.EOF and io.ErrUnexpectedEOF are not classified by default, since they come from
+// server-side reads (database connections, upstream bodies) as much as from malformed request bodies; register
+// TruncatedReadRule to classify them as ErrBadRequest.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - error: the classified error with a call stack, err unchanged if it is already classified
+//     or matches no rule, or nil if err is nil
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := DefinitionOf(err); ok {
+		return err
+	}
+
+	classificationMu.RLock()
+	rules := append(classificationRules[:len(classificationRules):len(classificationRules)], builtinClassificationRules...)
+	classificationMu.RUnlock()
+
+	for _, rule := range rules {
+		if rule.Match(err) {
+			cause := fmt.Errorf("%w: %w", rule.Err, err)
+
+			return track(&Error{
+				stack: stackFor(cause, 0),
+				error: cause,
+			})
+		}
+	}
+
+	return err
+}
+
+func isSentinel(sentinels ...error) func(error) bool {
+	return func(err error) bool {
+		for _, sentinel := range sentinels {
+			if Is(err, sentinel) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func isNetTimeout(err error) bool {
+	netErr, ok := AsType[net.Error](err)
+
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,163 @@
+// Package clouderrors maps AWS SDK v2, Google Cloud and Azure SDK errors onto the predefined error taxonomy,
+// keeping the raw service error in the chain so SDK-specific handling with As keeps working.
+package clouderrors
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/aws/smithy-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ceearrashee/errors"
+)
+
+type (
+	// grpcStatusError is implemented by Google Cloud client errors (apierror.APIError) and gRPC status errors.
+	grpcStatusError interface {
+		error
+		GRPCStatus() *status.Status
+	}
+
+	// httpCodeError is implemented by Google Cloud REST client errors (apierror.APIError).
+	httpCodeError interface {
+		error
+		HTTPCode() int
+	}
+
+	// httpStatusCodeError is implemented by AWS SDK v2 transport errors (smithyhttp.ResponseError).
+	httpStatusCodeError interface {
+		error
+		HTTPStatusCode() int
+	}
+)
+
+//nolint:gochecknoglobals
+var (
+	awsCodes = map[string]error{
+		"Throttling":                             errors.ErrTooManyRequests,
+		"ThrottlingException":                    errors.ErrTooManyRequests,
+		"ThrottledException":                     errors.ErrTooManyRequests,
+		"RequestThrottled":                       errors.ErrTooManyRequests,
+		"RequestThrottledException":              errors.ErrTooManyRequests,
+		"TooManyRequestsException":               errors.ErrTooManyRequests,
+		"ProvisionedThroughputExceededException": errors.ErrTooManyRequests,
+		"RequestLimitExceeded":                   errors.ErrTooManyRequests,
+		"SlowDown":                               errors.ErrTooManyRequests,
+		"AccessDenied":                           errors.ErrForbiddenAction,
+		"AccessDeniedException":                  errors.ErrForbiddenAction,
+		"UnauthorizedOperation":                  errors.ErrForbiddenAction,
+		"UnrecognizedClientException":            errors.ErrUnauthorized,
+		"InvalidClientTokenId":                   errors.ErrUnauthorized,
+		"ExpiredToken":                           errors.ErrUnauthorized,
+		"ExpiredTokenException":                  errors.ErrUnauthorized,
+		"NotFound":                               errors.ErrNotFound,
+		"NoSuchKey":                              errors.ErrNotFound,
+		"NoSuchBucket":                           errors.ErrNotFound,
+		"NoSuchEntity":                           errors.ErrNotFound,
+		"ResourceNotFoundException":              errors.ErrNotFound,
+		"ConditionalCheckFailedException":        errors.ErrPreconditionFailed,
+		"ConflictException":                      errors.ErrConflict,
+		"ResourceInUseException":                 errors.ErrConflict,
+		"ValidationException":                    errors.ErrValidation,
+	}
+
+	azureCodes = map[string]error{
+		"AuthorizationFailed":           errors.ErrForbiddenAction,
+		"AuthenticationFailed":          errors.ErrUnauthorized,
+		"ResourceNotFound":              errors.ErrNotFound,
+		"ResourceGroupNotFound":         errors.ErrNotFound,
+		"BlobNotFound":                  errors.ErrNotFound,
+		"ContainerNotFound":             errors.ErrNotFound,
+		"ConditionNotMet":               errors.ErrPreconditionFailed,
+		"Conflict":                      errors.ErrConflict,
+		"TooManyRequests":               errors.ErrTooManyRequests,
+		"ServerBusy":                    errors.ErrTooManyRequests,
+		"SubscriptionRequestsThrottled": errors.ErrTooManyRequests,
+	}
+)
+
+// Classify maps a cloud SDK error onto the predefined taxonomy. Throttling becomes ErrTooManyRequests
+// (retryable), access denied becomes ErrForbiddenAction, missing resources become ErrNotFound, and so on; bare
+// HTTP statuses are mapped with errors.SentinelForHTTPStatus.
+// The raw service error stays in the chain, so both Is on the sentinel and As on the SDK type succeed.
+//
+// Parameters:
+//   - err: the error returned by an AWS, Google Cloud or Azure SDK call
+//
+// Returns:
+//   - error: the classified error with a call stack, err unchanged if it cannot be classified, or nil if err is nil
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	sentinel := Sentinel(err)
+	if sentinel == nil {
+		return err
+	}
+
+	return errors.WrapSkipping(fmt.Errorf("%w: %w", sentinel, err), 1, "")
+}
+
+// Sentinel returns the predefined error a cloud SDK error maps to, without wrapping it.
+//
+// Parameters:
+//   - err: the error returned by an AWS, Google Cloud or Azure SDK call
+//
+// Returns:
+//   - error: the matching predefined error, or nil if the error is not recognized
+func Sentinel(err error) error {
+	if sentinel := awsSentinel(err); sentinel != nil {
+		return sentinel
+	}
+
+	if sentinel := azureSentinel(err); sentinel != nil {
+		return sentinel
+	}
+
+	return googleSentinel(err)
+}
+
+func awsSentinel(err error) error {
+	if apiErr, ok := errors.AsType[smithy.APIError](err); ok {
+		if sentinel, found := awsCodes[apiErr.ErrorCode()]; found {
+			return sentinel
+		}
+	}
+
+	if respErr, ok := errors.AsType[httpStatusCodeError](err); ok {
+		return errors.SentinelForHTTPStatus(respErr.HTTPStatusCode())
+	}
+
+	return nil
+}
+
+func azureSentinel(err error) error {
+	respErr, ok := errors.AsType[*azcore.ResponseError](err)
+	if !ok {
+		return nil
+	}
+
+	if sentinel, found := azureCodes[respErr.ErrorCode]; found {
+		return sentinel
+	}
+
+	return errors.SentinelForHTTPStatus(respErr.StatusCode)
+}
+
+func googleSentinel(err error) error {
+	if statusErr, ok := errors.AsType[grpcStatusError](err); ok {
+		// Unknown tells nothing about the failure, so the HTTP status decides instead when there is one.
+		if code := statusErr.GRPCStatus().Code(); code != codes.OK && code != codes.Unknown {
+			return errors.SentinelForGRPCCode(code.String())
+		}
+	}
+
+	if httpErr, ok := errors.AsType[httpCodeError](err); ok {
+		return errors.SentinelForHTTPStatus(httpErr.HTTPCode())
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+// Package cloudeventerrors embeds errors into CloudEvents extension attributes and parses them back, so
+// event-driven services propagate failures — with their code, reference code, fields and stack — across the
+// event mesh. The package has no CloudEvents SDK dependency: *event.Event of github.com/cloudevents/sdk-go/v2
+// satisfies Event, and Extensions / FromExtensions work with any representation of the attributes.
+//
+//	if err := cloudeventerrors.SetError(&evt, failure); err != nil {
+//		return err
+//	}
+//	...
+//	failure, ok, err := cloudeventerrors.ErrorOf(&evt)
+package cloudeventerrors
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// ExtensionCode is the extension attribute holding the registered code of the error, so brokers and triggers
+	// can filter failed events without decoding the envelope.
+	ExtensionCode = "errorcode"
+	// ExtensionReferenceCode is the extension attribute holding the reference code used to find the error in logs.
+	ExtensionReferenceCode = "errorref"
+	// ExtensionEnvelope is the extension attribute holding the JSON envelope of the error (see errors.ParseJSON),
+	// base64-encoded since extension values must be printable when mapped onto protocol headers.
+	ExtensionEnvelope = "errorenvelope"
+)
+
+type (
+	// Event is implemented by the events the error is embedded into, such as *event.Event of the CloudEvents SDK.
+	Event interface {
+		SetExtension(name string, value any)
+	}
+
+	// ExtensionReader is implemented by the events the error is read from, such as *event.Event of the CloudEvents SDK.
+	ExtensionReader interface {
+		Extensions() map[string]any
+	}
+)
+
+// SetError embeds err into the extension attributes of event.
+//
+// Parameters:
+//   - event: the event to annotate
+//   - err: the error to embed; if nil, the event is left untouched
+//
+// Returns:
+//   - error: an error if err cannot be serialized
+func SetError(event Event, err error) error {
+	extensions, marshalErr := Extensions(err)
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	for name, value := range extensions {
+		event.SetExtension(name, value)
+	}
+
+	return nil
+}
+
+// ErrorOf parses the error embedded into the extension attributes of event by SetError.
+//
+// Parameters:
+//   - event: the received event
+//
+// Returns:
+//   - error: the embedded error, or nil if the event carries none
+//   - bool: true if the event carries an error
+//   - error: an error if the embedded envelope is malformed
+func ErrorOf(event ExtensionReader) (error, bool, error) {
+	return FromExtensions(event.Extensions())
+}
+
+// Extensions returns the extension attributes describing err: its registered code, its reference code and its
+// JSON envelope. Unclassified errors get the code of errors.ErrInternalServerError.
+//
+// Parameters:
+//   - err: the error to describe
+//
+// Returns:
+//   - map[string]any: the extension attributes, or nil if err is nil
+//   - error: an error if err cannot be serialized
+func Extensions(err error) (map[string]any, error) {
+	if err == nil {
+		return nil, nil
+	}
+
+	marshaler, ok := err.(json.Marshaler) //nolint:errorlint
+	if !ok {
+		marshaler, _ = errors.Annotate(err).(json.Marshaler) //nolint:errorlint
+	}
+
+	envelope, marshalErr := json.Marshal(marshaler)
+	if marshalErr != nil {
+		return nil, errors.Wrap(marshalErr, "marshal error envelope")
+	}
+
+	def, ok := errors.DefinitionOf(err)
+	if !ok {
+		def, _ = errors.DefinitionOf(errors.ErrInternalServerError)
+	}
+
+	return map[string]any{
+		ExtensionCode:          string(def.Code),
+		ExtensionReferenceCode: errors.AutoCode(err),
+		ExtensionEnvelope:      base64.StdEncoding.EncodeToString(envelope),
+	}, nil
+}
+
+// FromExtensions parses the error described by extension attributes produced by Extensions, classified with
+// the code attribute: the registered sentinel of the code joins the chain, so Is matches it, whether the error is
+// parsed from the envelope or, when the envelope is missing because a producer only sets the code, rebuilt from
+// the registered definition of the code.
+//
+// Parameters:
+//   - extensions: the extension attributes of the received event
+//
+// Returns:
+//   - error: the described error, or nil if the attributes describe none
+//   - bool: true if the attributes describe an error
+//   - error: an error if the envelope is malformed
+func FromExtensions(extensions map[string]any) (error, bool, error) {
+	code := extension(extensions, ExtensionCode)
+	def, registered := errors.DefinitionByCode(errors.ErrorCode(code))
+	registered = registered && def.Err != nil
+
+	if envelope := extension(extensions, ExtensionEnvelope); envelope != "" {
+		data, decodeErr := base64.StdEncoding.DecodeString(envelope)
+		if decodeErr != nil {
+			return nil, false, errors.Wrap(decodeErr, "decode error envelope")
+		}
+
+		parsed, parseErr := errors.ParseJSON(data)
+		if parseErr != nil {
+			return nil, false, parseErr
+		}
+
+		// Envelopes do not carry the classification, the code attribute restores it.
+		switch {
+		case registered:
+			return fmt.Errorf("%w: %w", def.Err, parsed), true, nil
+		case code != "":
+			return errors.Annotate(parsed, errors.WithCode(errors.ErrorCode(code))), true, nil
+		default:
+			return parsed, true, nil
+		}
+	}
+
+	if code == "" {
+		return nil, false, nil
+	}
+
+	var (
+		err  = errors.New(code)
+		opts = []errors.Option{errors.WithCode(errors.ErrorCode(code))}
+	)
+
+	if registered {
+		// Wrapping keeps the registered sentinel matchable with Is without annotating it in place.
+		err, opts = fmt.Errorf("%w", def.Err), nil
+	}
+
+	if ref := extension(extensions, ExtensionReferenceCode); ref != "" {
+		opts = append(opts, errors.WithField(errors.FieldReferenceCode, ref))
+	}
+
+	return errors.Annotate(err, opts...), true, nil
+}
+
+// extension returns the string value of an extension attribute; the CloudEvents SDK decodes attributes received
+// in binary mode as strings, and attributes of the binary type as byte slices.
+func extension(extensions map[string]any, name string) string {
+	switch value := extensions[name].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
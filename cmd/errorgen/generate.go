@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ceearrashee/errors"
+)
+
+type (
+	// catalogue is the declarative list of error definitions of a package.
+	catalogue struct {
+		Package string       `yaml:"package"`
+		Errors  []definition `yaml:"errors"`
+	}
+
+	// definition is a single catalogue entry.
+	definition struct {
+		Name       string `yaml:"name"`
+		Code       string `yaml:"code"`
+		Message    string `yaml:"message"`
+		HTTPStatus int    `yaml:"http_status"`
+		Retryable  bool   `yaml:"retryable"`
+		I18nKey    string `yaml:"i18n_key"`
+	}
+)
+
+var sourceTemplate = template.Must(template.New("errors").Parse(`// Code generated by errorgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"fmt"
+
+	"github.com/ceearrashee/errors"
+)
+
+// error codes...
+const (
+{{- range .Errors }}
+	Code{{ .Name }} errors.ErrorCode = {{ printf "%q" .Code }}
+{{- end }}
+)
+
+// errors...
+var (
+{{- range .Errors }}
+	Err{{ .Name }} = errors.New({{ printf "%q" .Message }})
+{{- end }}
+)
+
+func init() { //nolint:gochecknoinits
+{{- range .Errors }}
+	errors.RegisterDefinition(errors.ErrorDefinition{
+		Code:       Code{{ .Name }},
+		Err:        Err{{ .Name }},
+		HTTPStatus: {{ .HTTPStatus }},
+		Retryable:  {{ .Retryable }},
+		MessageKey: {{ printf "%q" .I18nKey }},
+	})
+{{- end }}
+}
+{{ range .Errors }}
+// New{{ .Name }} creates an error classified as Err{{ .Name }} with a formatted description and a stack trace.
+func New{{ .Name }}(format string, args ...any) error {
+	return errors.Wrapf(Err{{ .Name }}, format, args...)
+}
+
+// Wrap{{ .Name }} wraps err, classifying it as Err{{ .Name }} with a formatted description and a stack trace.
+// err stays in the chain.
+func Wrap{{ .Name }}(err error, format string, args ...any) error {
+	return errors.Wrapf(fmt.Errorf("%w: %w", Err{{ .Name }}, err), format, args...)
+}
+{{ end }}`))
+
+func readCatalogue(path string) (catalogue, error) {
+	var result catalogue
+
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to read catalogue %s", path)
+	}
+
+	// JSON is a subset of YAML, so a single decoder handles both formats.
+	if err = yaml.Unmarshal(data, &result); err != nil {
+		return result, errors.Wrapf(err, "failed to parse catalogue %s", path)
+	}
+
+	return result, validate(result)
+}
+
+func validate(c catalogue) error {
+	if c.Package == "" {
+		return errors.New("catalogue package is required")
+	}
+
+	seen := make(map[string]bool, len(c.Errors))
+
+	for _, def := range c.Errors {
+		switch {
+		case def.Name == "" || !unicode.IsUpper([]rune(def.Name)[0]):
+			return errors.Newf("invalid error name %q: must be an exported Go identifier", def.Name)
+		case def.Code == "":
+			return errors.Newf("error %s has no code", def.Name)
+		case seen[def.Code]:
+			return errors.Newf("duplicate error code %q", def.Code)
+		}
+
+		seen[def.Code] = true
+	}
+
+	return nil
+}
+
+func generate(c catalogue) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := sourceTemplate.Execute(&buffer, c); err != nil {
+		return nil, errors.Wrap(err, "failed to render generated code")
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format generated code")
+	}
+
+	return source, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGenerateMatchesGolden(t *testing.T) {
+	t.Parallel()
+
+	catalogue, err := readCatalogue("testdata/catalogue.yaml")
+	if err != nil {
+		t.Fatalf("readCatalogue: %v", err)
+	}
+
+	source, err := generate(catalogue)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	golden, err := os.ReadFile("internal/golden/errors_gen.go")
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+
+	if !bytes.Equal(source, golden) {
+		t.Fatalf("generated code differs from internal/golden/errors_gen.go, run go generate ./cmd/errorgen/...:\n%s", source)
+	}
+}
@@ -0,0 +1,5 @@
+// Package golden holds the code errorgen generates from testdata/catalogue.yaml, compared with the output of the
+// generator by its tests and compiled so the behavior of the generated constructors is tested too.
+package golden
+
+//go:generate go run ../.. -in ../../testdata/catalogue.yaml -out errors_gen.go
@@ -0,0 +1,60 @@
+// Code generated by errorgen. DO NOT EDIT.
+
+package golden
+
+import (
+	"fmt"
+
+	"github.com/ceearrashee/errors"
+)
+
+// error codes...
+const (
+	CodeQuotaExceeded   errors.ErrorCode = "errorgen_golden_quota_exceeded"
+	CodeInvoiceNotFound errors.ErrorCode = "errorgen_golden_invoice_not_found"
+)
+
+// errors...
+var (
+	ErrQuotaExceeded   = errors.New("quota exceeded")
+	ErrInvoiceNotFound = errors.New("invoice not found")
+)
+
+func init() { //nolint:gochecknoinits
+	errors.RegisterDefinition(errors.ErrorDefinition{
+		Code:       CodeQuotaExceeded,
+		Err:        ErrQuotaExceeded,
+		HTTPStatus: 429,
+		Retryable:  true,
+		MessageKey: "golden.quota_exceeded",
+	})
+	errors.RegisterDefinition(errors.ErrorDefinition{
+		Code:       CodeInvoiceNotFound,
+		Err:        ErrInvoiceNotFound,
+		HTTPStatus: 404,
+		Retryable:  false,
+		MessageKey: "",
+	})
+}
+
+// NewQuotaExceeded creates an error classified as ErrQuotaExceeded with a formatted description and a stack trace.
+func NewQuotaExceeded(format string, args ...any) error {
+	return errors.Wrapf(ErrQuotaExceeded, format, args...)
+}
+
+// WrapQuotaExceeded wraps err, classifying it as ErrQuotaExceeded with a formatted description and a stack trace.
+// err stays in the chain.
+func WrapQuotaExceeded(err error, format string, args ...any) error {
+	return errors.Wrapf(fmt.Errorf("%w: %w", ErrQuotaExceeded, err), format, args...)
+}
+
+// NewInvoiceNotFound creates an error classified as ErrInvoiceNotFound with a formatted description and a stack trace.
+func NewInvoiceNotFound(format string, args ...any) error {
+	return errors.Wrapf(ErrInvoiceNotFound, format, args...)
+}
+
+// WrapInvoiceNotFound wraps err, classifying it as ErrInvoiceNotFound with a formatted description and a stack trace.
+// err stays in the chain.
+func WrapInvoiceNotFound(err error, format string, args ...any) error {
+	return errors.Wrapf(fmt.Errorf("%w: %w", ErrInvoiceNotFound, err), format, args...)
+}
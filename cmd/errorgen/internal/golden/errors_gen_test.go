@@ -0,0 +1,27 @@
+package golden_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+	"github.com/ceearrashee/errors/cmd/errorgen/internal/golden"
+)
+
+func TestWrapKeepsCause(t *testing.T) {
+	t.Parallel()
+
+	err := golden.WrapQuotaExceeded(io.ErrUnexpectedEOF, "charge invoice %d", 42)
+
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Is(%v, io.ErrUnexpectedEOF) = false, want true", err)
+	}
+
+	if !errors.Is(err, golden.ErrQuotaExceeded) {
+		t.Fatalf("Is(%v, ErrQuotaExceeded) = false, want true", err)
+	}
+
+	if got := errors.CodeOf(err); got != golden.CodeQuotaExceeded {
+		t.Fatalf("CodeOf = %q, want %q", got, golden.CodeQuotaExceeded)
+	}
+}
@@ -0,0 +1,66 @@
+// Command errorgen generates typed error constructors and catalogue registration code
+// from a declarative YAML or JSON catalogue of error definitions.
+//
+// Usage:
+//
+//	//go:generate errorgen -in errors.yaml -out errors_gen.go
+//
+// Catalogue format:
+//
+//	package: billing
+//	errors:
+//	  - name: QuotaExceeded
+//	    code: quota_exceeded
+//	    message: quota exceeded
+//	    http_status: 429
+//	    retryable: true
+//	    i18n_key: billing.quota_exceeded
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the YAML or JSON error catalogue")
+	out := flag.String("out", "", "path of the generated Go file (stdout if empty)")
+	pkg := flag.String("package", "", "package name of the generated file (overrides the catalogue)")
+
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2) //nolint:mnd
+	}
+
+	if err := run(*in, *out, *pkg); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "errorgen:", err) //nolint:errcheck,revive
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg string) error {
+	catalogue, err := readCatalogue(in)
+	if err != nil {
+		return err
+	}
+
+	if pkg != "" {
+		catalogue.Package = pkg
+	}
+
+	source, err := generate(catalogue)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+
+		return err //nolint:wrapcheck
+	}
+
+	return os.WriteFile(out, source, 0o644) //nolint:gosec,mnd,wrapcheck
+}
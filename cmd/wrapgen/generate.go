@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ceearrashee/errors"
+)
+
+// errorsPath is the import path of the package wrapping the errors in the generated code.
+const errorsPath = "github.com/ceearrashee/errors"
+
+type (
+	// target is the interface to wrap.
+	target struct {
+		pkg   *types.Package
+		name  string
+		iface *types.Interface
+	}
+
+	// wrapper is the data rendered into the generated file.
+	wrapper struct {
+		Package    string
+		Interface  string
+		Name       string
+		StdImports []importSpec
+		Imports    []importSpec
+		Methods    []method
+	}
+
+	// importSpec is an import of the generated file; Name is set when the package is aliased.
+	importSpec struct {
+		Name string
+		Path string
+	}
+
+	// method is a method of the wrapped interface.
+	method struct {
+		Name    string
+		Params  string
+		Args    string
+		Results string
+		Vars    string
+		Errors  []string
+	}
+
+	// imports assigns unique names to the packages referenced by the generated code.
+	imports struct {
+		self   *types.Package
+		byPath map[string]string
+		names  map[string]bool
+		specs  []importSpec
+	}
+)
+
+// resultVarPattern matches the names of the result variables of the generated methods.
+var resultVarPattern = regexp.MustCompile(`^r\d+$`)
+
+var sourceTemplate = template.Must(template.New("wrapper").Parse(`// Code generated by wrapgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .StdImports }}
+	{{ with .Name }}{{ . }} {{ end }}{{ printf "%q" .Path }}
+{{- end }}
+{{ range .Imports }}
+	{{ with .Name }}{{ . }} {{ end }}{{ printf "%q" .Path }}
+{{- end }}
+)
+
+// {{ .Name }} wraps {{ .Interface }}, wrapping every returned error with the method name and a stack trace.
+type {{ .Name }} struct {
+	next {{ .Interface }}
+}
+
+var _ {{ .Interface }} = (*{{ .Name }})(nil)
+
+// New{{ .Name }} returns a {{ .Interface }} wrapping the errors returned by next.
+func New{{ .Name }}(next {{ .Interface }}) *{{ .Name }} {
+	return &{{ .Name }}{next: next}
+}
+{{ range $m := .Methods }}
+// {{ $m.Name }} calls {{ $.Interface }}.{{ $m.Name }}{{ if $m.Errors }}, wrapping the returned errors{{ end }}.
+func (w *{{ $.Name }}) {{ $m.Name }}({{ $m.Params }}) {{ $m.Results }} {
+{{- if not $m.Vars }}
+	w.next.{{ $m.Name }}({{ $m.Args }})
+{{- else if not $m.Errors }}
+	return w.next.{{ $m.Name }}({{ $m.Args }})
+{{- else }}
+	{{ $m.Vars }} := w.next.{{ $m.Name }}({{ $m.Args }})
+{{- range $m.Errors }}
+	if {{ . }} != nil {
+		{{ . }} = errors.Wrap({{ . }}, {{ printf "%q" (print $.Interface "." $m.Name) }})
+	}
+{{- end }}
+
+	return {{ $m.Vars }}
+{{- end }}
+}
+{{ end }}`))
+
+func loadInterface(dir, typeName string) (target, error) {
+	// Dependencies are type-checked from source, so loading does not depend on the export data format of the toolchain.
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return target{}, errors.Wrapf(err, "failed to load package in %s", dir)
+	}
+
+	if len(pkgs) != 1 || len(pkgs[0].Errors) > 0 || pkgs[0].Types == nil {
+		if len(pkgs) == 1 && len(pkgs[0].Errors) > 0 {
+			return target{}, errors.Wrapf(pkgs[0].Errors[0], "failed to load package in %s", dir)
+		}
+
+		return target{}, errors.Newf("no package found in %s", dir)
+	}
+
+	object := pkgs[0].Types.Scope().Lookup(typeName)
+	if object == nil {
+		return target{}, errors.Newf("type %s not found in %s", typeName, pkgs[0].PkgPath)
+	}
+
+	named, ok := object.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return target{}, errors.Newf("type %s must be a non-generic named interface", typeName)
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return target{}, errors.Newf("type %s is not an interface", typeName)
+	}
+
+	return target{pkg: pkgs[0].Types, name: typeName, iface: iface}, nil
+}
+
+func generate(t target, name string) ([]byte, error) {
+	imps := &imports{self: t.pkg, byPath: map[string]string{}, names: map[string]bool{}}
+	imps.add(errorsPath, "errors")
+
+	data := wrapper{Package: t.pkg.Name(), Interface: t.name, Name: name}
+
+	errorType := types.Universe.Lookup("error").Type()
+
+	for i := range t.iface.NumMethods() {
+		fn := t.iface.Method(i)
+		if !fn.Exported() && fn.Pkg() != t.pkg {
+			return nil, errors.Newf("method %s of %s is unexported in another package", fn.Name(), t.name)
+		}
+
+		sig, _ := fn.Type().(*types.Signature) //nolint:errcheck
+		m := method{Name: fn.Name()}
+
+		params := make([]string, 0, sig.Params().Len())
+		args := make([]string, 0, sig.Params().Len())
+
+		for j := range sig.Params().Len() {
+			param := sig.Params().At(j)
+			paramName := param.Name()
+
+			if !token.IsIdentifier(paramName) || paramName == "_" || paramName == "w" || paramName == "errors" ||
+				resultVarPattern.MatchString(paramName) {
+				paramName = "p" + strconv.Itoa(j)
+			}
+
+			typ := types.TypeString(param.Type(), imps.qualifier)
+			arg := paramName
+
+			if sig.Variadic() && j == sig.Params().Len()-1 {
+				typ = "..." + strings.TrimPrefix(typ, "[]")
+				arg += "..."
+			}
+
+			params = append(params, paramName+" "+typ)
+			args = append(args, arg)
+		}
+
+		results := make([]string, 0, sig.Results().Len())
+		vars := make([]string, 0, sig.Results().Len())
+
+		for j := range sig.Results().Len() {
+			result := sig.Results().At(j)
+			varName := "r" + strconv.Itoa(j)
+
+			results = append(results, types.TypeString(result.Type(), imps.qualifier))
+			vars = append(vars, varName)
+
+			if types.Identical(result.Type(), errorType) {
+				m.Errors = append(m.Errors, varName)
+			}
+		}
+
+		m.Params = strings.Join(params, ", ")
+		m.Args = strings.Join(args, ", ")
+		m.Vars = strings.Join(vars, ", ")
+
+		if len(results) > 1 {
+			m.Results = "(" + strings.Join(results, ", ") + ")"
+		} else {
+			m.Results = strings.Join(results, "")
+		}
+
+		data.Methods = append(data.Methods, m)
+	}
+
+	for _, spec := range imps.specs {
+		// Standard library paths have no dot in their first element.
+		if first, _, _ := strings.Cut(spec.Path, "/"); strings.Contains(first, ".") {
+			data.Imports = append(data.Imports, spec)
+		} else {
+			data.StdImports = append(data.StdImports, spec)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	if err := sourceTemplate.Execute(&buffer, data); err != nil {
+		return nil, errors.Wrap(err, "failed to render generated code")
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format generated code")
+	}
+
+	return source, nil
+}
+
+// qualifier names the packages of the types rendered into the generated code, importing them as needed.
+func (i *imports) qualifier(pkg *types.Package) string {
+	if pkg == i.self {
+		return ""
+	}
+
+	if name, ok := i.byPath[pkg.Path()]; ok {
+		return name
+	}
+
+	return i.add(pkg.Path(), pkg.Name())
+}
+
+// add imports path under its package name, aliased with a numeric suffix if the name is already taken.
+func (i *imports) add(path, name string) string {
+	unique := name
+	for n := 2; i.names[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+
+	i.byPath[path] = unique
+	i.names[unique] = true
+
+	spec := importSpec{Path: path}
+	if unique != name {
+		spec.Name = unique
+	}
+
+	i.specs = append(i.specs, spec)
+
+	return unique
+}
@@ -0,0 +1,65 @@
+// Command wrapgen generates a decorator of an interface wrapping every error returned by its methods
+// with the interface and method names and a stack trace, so repositories and clients get consistent wrapping
+// without hand-written boilerplate.
+//
+// Usage:
+//
+//	//go:generate wrapgen -type UserRepository -out userRepository_wrap.go
+//
+// The generated file declares, in the package of the interface:
+//
+//	type UserRepositoryWithErrors struct{ ... }
+//
+//	func NewUserRepositoryWithErrors(next UserRepository) *UserRepositoryWithErrors
+//
+// whose methods call next and wrap its errors as errors.Wrap(err, "UserRepository.Get").
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	typeName := flag.String("type", "", "name of the interface to wrap")
+	out := flag.String("out", "", "path of the generated Go file (stdout if empty)")
+	name := flag.String("name", "", "name of the generated type (<type>WithErrors if empty)")
+	dir := flag.String("dir", ".", "directory of the package declaring the interface")
+
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2) //nolint:mnd
+	}
+
+	if err := run(*dir, *typeName, *name, *out); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "wrapgen:", err) //nolint:errcheck,revive
+		os.Exit(1)
+	}
+}
+
+func run(dir, typeName, name, out string) error {
+	target, err := loadInterface(dir, typeName)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = typeName + "WithErrors"
+	}
+
+	source, err := generate(target, name)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+
+		return err //nolint:wrapcheck
+	}
+
+	return os.WriteFile(out, source, 0o644) //nolint:gosec,mnd,wrapcheck
+}
@@ -0,0 +1,16 @@
+// Command wraplint runs the wrap hygiene analyzer.
+//
+// Usage:
+//
+//	go vet -vettool=$(which wraplint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/ceearrashee/errors/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type (
+	// Collector accumulates non-fatal errors during a request, for partial-success APIs.
+	// It is safe for concurrent use.
+	Collector struct {
+		mu   sync.Mutex
+		errs []error
+	}
+
+	// Warning is the client-facing representation of a collected error, suitable for a response
+	// "warnings" extension.
+	Warning struct {
+		Code    ErrorCode `json:"code,omitempty"`
+		Message string    `json:"message"`
+	}
+
+	// Context key type to avoid collisions.
+	ctxKey int
+)
+
+const (
+	collectorKey ctxKey = iota
+	failContextKey
+	reportDeadlineKey
+)
+
+// WithCollector attaches a new Collector to the context.
+//
+// Parameters:
+//   - ctx: the parent context to derive from
+//
+// Returns:
+//   - context.Context: derived context containing the Collector
+//   - *Collector: the attached collector
+func WithCollector(ctx context.Context) (context.Context, *Collector) {
+	collector := &Collector{}
+
+	return context.WithValue(ctx, collectorKey, collector), collector
+}
+
+// CollectorFrom returns the Collector attached to the context.
+//
+// Parameters:
+//   - ctx: the context to inspect
+//
+// Returns:
+//   - *Collector: the attached collector, or nil if there is none
+func CollectorFrom(ctx context.Context) *Collector {
+	collector, _ := ctx.Value(collectorKey).(*Collector)
+
+	return collector
+}
+
+// Collect adds a non-fatal error to the Collector attached to the context.
+//
+// Parameters:
+//   - ctx: the context carrying the collector
+//   - err: the error to collect; nil is ignored
+//
+// Returns:
+//   - bool: true if the error was collected, false if it is nil or the context has no collector
+func Collect(ctx context.Context, err error) bool {
+	collector := CollectorFrom(ctx)
+	if collector == nil || err == nil {
+		return false
+	}
+
+	collector.Add(err)
+
+	return true
+}
+
+// CollectorMiddleware attaches a Collector to every request and calls onFinish with the collected errors
+// once the handler returns, so they can be logged or reported as warnings.
+//
+// Parameters:
+//   - next: the handler to wrap
+//   - onFinish: called after the handler with the request and its collected errors; not called when nothing was collected
+//
+// Returns:
+//   - http.Handler: the wrapping handler
+func CollectorMiddleware(next http.Handler, onFinish func(r *http.Request, errs []error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, collector := WithCollector(r.Context())
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(w, r)
+
+		if errs := collector.Errors(); len(errs) > 0 && onFinish != nil {
+			onFinish(r, errs)
+		}
+	})
+}
+
+// Add appends a non-fatal error to the collector.
+//
+// Parameters:
+//   - err: the error to collect; nil is ignored
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.errs = append(c.errs, err)
+	c.mu.Unlock()
+}
+
+// Errors returns a copy of the collected errors.
+//
+// Returns:
+//   - []error: the collected errors in the order they were added
+func (c *Collector) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]error(nil), c.errs...)
+}
+
+// Len returns the number of collected errors.
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.errs)
+}
+
+// Err returns the collected errors combined into an Aggregate.
+//
+// Returns:
+//   - error: nil if nothing was collected, the single error, or an *Aggregate otherwise
+func (c *Collector) Err() error {
+	return Append(nil, c.Errors()...)
+}
+
+// Warnings renders the collected errors for a response "warnings" extension. Warnings are sent to clients, so each
+// carries the message of the registered definition of its error, or the scrubbed error message without one.
+//
+// Returns:
+//   - []Warning: one warning per collected error, or nil if nothing was collected
+func (c *Collector) Warnings() []Warning {
+	errs := c.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	warnings := make([]Warning, 0, len(errs))
+	for _, err := range errs {
+		warnings = append(warnings, Warning{Code: CodeOf(err), Message: warningMessage(err)})
+	}
+
+	return warnings
+}
+
+// warningMessage returns the client-safe message of a warning: the message of its registered definition, or the
+// scrubbed error message.
+func warningMessage(err error) string {
+	if def, ok := DefinitionOf(err); ok && def.Message != "" {
+		return def.Message
+	}
+
+	return ScrubMessage(err.Error())
+}
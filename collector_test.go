@@ -0,0 +1,29 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestCollectorWarningsUseRegisteredMessages(t *testing.T) {
+	t.Parallel()
+
+	var collector errors.Collector
+
+	collector.Add(errors.Wrap(errQuota, "charge tenant acme"))
+	collector.Add(errors.New("unknown key \"colour\""))
+
+	warnings := collector.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Warnings = %v, want 2 warnings", warnings)
+	}
+
+	if got := warnings[0]; got.Code != "test_quota_exceeded" || got.Message != "quota exceeded" {
+		t.Fatalf("first warning = %+v, want the registered code and message", got)
+	}
+
+	if got := warnings[1].Message; got != "unknown key \"colour\"" {
+		t.Fatalf("second warning message = %q, want the error message", got)
+	}
+}
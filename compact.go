@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// DefaultCompactDepth is the number of wrappers above which Compact collapses a chain.
+	DefaultCompactDepth = 4
+	// CompactSeparator is the separator placed between the descriptions merged by Compact.
+	CompactSeparator = " → "
+)
+
+var compactDepth atomic.Int64 //nolint:gochecknoglobals
+
+func init() { //nolint:gochecknoinits
+	compactDepth.Store(DefaultCompactDepth)
+}
+
+// SetCompactDepth sets the number of wrappers above which Compact collapses a chain.
+//
+// Parameters:
+//   - depth: the maximum number of wrappers kept as is (DefaultCompactDepth by default)
+func SetCompactDepth(depth int) {
+	compactDepth.Store(int64(depth))
+}
+
+// Compact collapses the wrappers of a chain longer than the compact depth (see SetCompactDepth) into a single
+// *Error, keeping payloads and span tags bounded before serialization. The merged error describes the chain as
+// "a → b → c", holds the fields, tags, attachments and named causes of every wrapper (the outermost value wins)
+// and the innermost stack. Registered sentinels, errors of other packages and handoffs below the wrappers are
+// kept unchanged, so Is and As keep matching them; aggregates are compacted member by member, and chains below
+// fmt.Errorf or errors.Join wrappers are compacted as well, rebuilding the wrappers around them.
+//
+// Parameters:
+//   - err: the error to compact
+//
+// Returns:
+//   - error: the compacted error, err itself if its chain is short enough, or nil if err is nil
+func Compact(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	depth := int(compactDepth.Load())
+	walker := &chainWalker{isSentinel: isRegisteredSentinel(), join: func(members ...error) error {
+		return &Aggregate{errs: members}
+	}}
+	walker.level = func(e *Error, walk func(error) (error, bool)) error {
+		return compact(e, walker.isSentinel, walk, depth)
+	}
+
+	return walker.rebuild(err)
+}
+
+func compact(err *Error, isSentinel func(*Error) bool, walk func(error) (error, bool), depth int) error {
+	var (
+		levels []*Error
+		leaf   error = err
+	)
+
+	for {
+		level, ok := leaf.(*Error) //nolint:errorlint
+		if !ok || level.error == nil || isSentinel(level) || level.handoff != handoffNone || level.wire != nil {
+			break
+		}
+
+		levels = append(levels, level)
+		leaf = level.error
+	}
+
+	if len(levels) == 0 {
+		return err
+	}
+
+	leaf, changed := walk(leaf)
+
+	if len(levels) <= depth {
+		if !changed {
+			return err
+		}
+
+		for i := len(levels) - 1; i >= 0; i-- {
+			level := levels[i].clone()
+			level.error = leaf
+			leaf = level
+		}
+
+		return leaf
+	}
+
+	merged := &Error{error: leaf}
+	descriptions := make([]string, 0, len(levels))
+
+	for _, level := range levels {
+		if level.Description != "" {
+			descriptions = append(descriptions, level.Description)
+		}
+
+		if level.stack != nil {
+			merged.stack = level.stack
+		}
+
+		merged.fields = mergeMissing(merged.fields, level.fields)
+		merged.causes = mergeMissing(merged.causes, level.causes)
+		merged.attachments = append(merged.attachments, level.attachments...)
+		merged.warnings = append(merged.warnings, level.warnings...)
+
+		for _, tag := range level.tags {
+			if !slices.Contains(merged.tags, tag) {
+				merged.tags = append(merged.tags, tag)
+			}
+		}
+
+		merged.code = cmp.Or(merged.code, level.code)
+		merged.template = cmp.Or(merged.template, level.template)
+		merged.instanceID = cmp.Or(merged.instanceID, level.instanceID)
+		merged.codePrefix = cmp.Or(merged.codePrefix, level.codePrefix)
+
+		if merged.upstream == nil {
+			merged.upstream = level.upstream
+		}
+	}
+
+	merged.Description = strings.Join(descriptions, CompactSeparator)
+
+	return merged
+}
+
+// mergeMissing adds to dst the entries of src whose key it does not hold yet, allocating dst when needed.
+func mergeMissing[M ~map[string]V, V any](dst, src M) M {
+	if len(src) == 0 {
+		return dst
+	}
+
+	if dst == nil {
+		return maps.Clone(src)
+	}
+
+	for key, value := range src {
+		if _, exists := dst[key]; !exists {
+			dst[key] = value
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,160 @@
+// Package compat exposes the API of the standard library errors package and of github.com/pkg/errors
+// implemented on the types of github.com/ceearrashee/errors, so existing code migrates with a single import rewrite:
+//
+//	import "github.com/pkg/errors"  →  import errors "github.com/ceearrashee/errors/compat"
+//
+// Unlike github.com/pkg/errors, WithMessage and WithMessagef record a call stack as well, since every error of
+// this package can carry one; reporters use the deepest stack of the chain.
+package compat
+
+import (
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/ceearrashee/errors"
+)
+
+// ErrUnsupported is a wrapper for errors.ErrUnsupported.
+var ErrUnsupported = stdErrors.ErrUnsupported //nolint:gochecknoglobals
+
+// New returns an error with the supplied message and the call stack at the point it was called.
+//
+// Parameters:
+//   - message: the error message
+//
+// Returns:
+//   - error: the new error
+func New(message string) error {
+	return errors.NewSkipping(1, message)
+}
+
+// Errorf formats according to a format specifier and returns the result as an error with a call stack.
+// The %w verb wraps its operand, as with fmt.Errorf.
+//
+// Parameters:
+//   - format: the format string
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the new error
+func Errorf(format string, args ...any) error {
+	return errors.WrapSkipping(fmt.Errorf(format, args...), 1, "") //nolint:err113
+}
+
+// Wrap returns an error annotating err with a call stack and the supplied message.
+//
+// Parameters:
+//   - err: the error to wrap
+//   - message: the message prepended to the message of err
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil
+func Wrap(err error, message string) error {
+	return errors.WrapSkipping(err, 1, message)
+}
+
+// Wrapf returns an error annotating err with a call stack and the format specifier.
+//
+// Parameters:
+//   - err: the error to wrap
+//   - format: the format string of the message prepended to the message of err
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WrapSkipping(err, 1, fmt.Sprintf(format, args...))
+}
+
+// WithMessage annotates err with a new message.
+//
+// Parameters:
+//   - err: the error to annotate
+//   - message: the message prepended to the message of err
+//
+// Returns:
+//   - error: the annotated error, or nil if err is nil
+func WithMessage(err error, message string) error {
+	return errors.WrapSkipping(err, 1, message)
+}
+
+// WithMessagef annotates err with the format specifier.
+//
+// Parameters:
+//   - err: the error to annotate
+//   - format: the format string of the message prepended to the message of err
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the annotated error, or nil if err is nil
+func WithMessagef(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WrapSkipping(err, 1, fmt.Sprintf(format, args...))
+}
+
+// WithStack annotates err with a call stack at the point WithStack was called, keeping its message.
+//
+// Parameters:
+//   - err: the error to annotate
+//
+// Returns:
+//   - error: the annotated error, or nil if err is nil
+func WithStack(err error) error {
+	return errors.WrapSkipping(err, 1, "")
+}
+
+// Cause returns the underlying cause of the error: it unwraps err through both Unwrap() error
+// and the Cause() error method of github.com/pkg/errors until reaching an error that wraps nothing.
+//
+// Parameters:
+//   - err: the error to unwrap
+//
+// Returns:
+//   - error: the root cause, or nil if err is nil
+func Cause(err error) error {
+	for err != nil {
+		var next error
+
+		switch x := err.(type) { //nolint:errorlint
+		case interface{ Cause() error }:
+			next = x.Cause()
+		case interface{ Unwrap() error }:
+			next = x.Unwrap()
+		}
+
+		if next == nil {
+			return err
+		}
+
+		err = next
+	}
+
+	return nil
+}
+
+// Is is a wrapper for errors.Is.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As is a wrapper for errors.As.
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
+// Unwrap is a wrapper for errors.Unwrap.
+func Unwrap(err error) error {
+	return errors.Unwrap(err)
+}
+
+// Join is a wrapper for errors.Join.
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// WrapIf wraps err with a description and a call stack only when cond is true.
+//
+// Parameters:
+//   - cond: whether to wrap the error
+//   - err: the original error
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - error: the wrapped error if cond is true, err unchanged otherwise, or nil if err is nil
+func WrapIf(cond bool, err error, description string) error {
+	if err == nil && cond {
+		recordNilWrap("WrapIf", description, 0)
+	}
+
+	if err == nil || !cond {
+		return err
+	}
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, 0),
+		error:       err,
+	})
+}
+
+// WrapUnless classifies err with the sentinel and wraps it with a description, unless err is already
+// classified with that sentinel, in which case it is returned unchanged to avoid re-wrapping.
+//
+// Parameters:
+//   - err: the original error
+//   - sentinel: the classification to apply
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - error: the classified error, err unchanged if it already matches the sentinel, or nil if err is nil
+func WrapUnless(err, sentinel error, description string) error {
+	if err == nil {
+		recordNilWrap("WrapUnless", description, 0)
+
+		return nil
+	}
+
+	if Is(err, sentinel) {
+		return err
+	}
+
+	if sentinel == nil {
+		reportMisuse("WrapUnless called with a nil sentinel error")
+	}
+
+	cause := fmt.Errorf("%w: %w", sentinel, err)
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(cause, 0),
+		error:       cause,
+	})
+}
+
+// Ignore returns nil if err matches any of the targets, such as ErrNotFound or io.EOF, and err otherwise.
+//
+// Parameters:
+//   - err: the error to filter
+//   - targets: the errors to ignore, matched with Is
+//
+// Returns:
+//   - error: nil if err matches a target, err unchanged otherwise
+func Ignore(err error, targets ...error) error {
+	for _, target := range targets {
+		if Is(err, target) {
+			return nil
+		}
+	}
+
+	return err
+}
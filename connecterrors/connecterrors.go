@@ -0,0 +1,80 @@
+// Package connecterrors converts errors of github.com/ceearrashee/errors to and from Connect (connectrpc.com) errors
+// and provides an interceptor performing the conversion and reporting at the Connect boundary:
+//
+//	path, handler := greetv1connect.NewGreetServiceHandler(svc, connect.WithInterceptors(
+//		connecterrors.NewInterceptor(connecterrors.Report(datadog.HandleError)),
+//	))
+package connecterrors
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ceearrashee/errors"
+	"github.com/ceearrashee/errors/grpcerrors"
+)
+
+const (
+	// MetaCode is the metadata key holding the registered error code.
+	MetaCode = "X-Error-Code"
+	// MetaReferenceCode is the metadata key holding the reference code used to find the error in logs.
+	MetaReferenceCode = "X-Error-Reference-Code"
+)
+
+// ToConnectError converts an error into a Connect error whose code is the gRPC code of its registered definition
+// (see grpcerrors.CodeOf) and whose metadata carries the registered code and reference code. Unclassified errors
+// become CodeInternal.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - *connect.Error: the equivalent Connect error, the existing one if the chain already contains one, or nil if
+//     err is nil
+func ToConnectError(err error) *connect.Error {
+	if err == nil {
+		return nil
+	}
+
+	if connectErr, ok := errors.AsType[*connect.Error](err); ok {
+		return connectErr
+	}
+
+	// Connect codes are the gRPC codes.
+	connectErr := connect.NewError(connect.Code(grpcerrors.CodeOf(err)), err)
+	connectErr.Meta().Set(MetaReferenceCode, errors.AutoCode(err))
+
+	if registered := errors.CodeOf(err); registered != "" {
+		connectErr.Meta().Set(MetaCode, string(registered))
+	}
+
+	return connectErr
+}
+
+// FromConnectError classifies an error returned by a Connect client by its code, with the gRPC code mapping of
+// grpcerrors.SentinelOf (including errors.RegisterGRPCCodeMapping), e.g. CodeNotFound becomes ErrNotFound.
+// The original Connect error stays in the chain.
+//
+// Parameters:
+//   - err: the error returned by a Connect client call
+//
+// Returns:
+//   - error: the classified error with a call stack, err unchanged if it holds no Connect error, or nil if err is nil
+func FromConnectError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := errors.AsType[*connect.Error](err); !ok {
+		return err
+	}
+
+	sentinel := grpcerrors.SentinelOf(codes.Code(connect.CodeOf(err)))
+	if sentinel == nil || errors.Is(err, sentinel) {
+		return err
+	}
+
+	return errors.WrapSkipping(fmt.Errorf("%w: %w", sentinel, err), 1, "")
+}
@@ -0,0 +1,109 @@
+package connecterrors
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+type (
+	// Option customizes the interceptor.
+	Option func(*Interceptor)
+
+	// Interceptor converts handler errors with ToConnectError and sends them to the reporter,
+	// and classifies errors received by clients with FromConnectError.
+	Interceptor struct {
+		report func(ctx context.Context, err error) error
+	}
+)
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// Report sets the reporter handler errors are sent to, e.g. datadog.HandleError.
+//
+// Parameters:
+//   - report: the reporter to call
+//
+// Returns:
+//   - Option: the option applying the reporter
+func Report(report func(ctx context.Context, err error) error) Option {
+	return func(i *Interceptor) {
+		i.report = report
+	}
+}
+
+// NewInterceptor creates an interceptor usable on both Connect handlers and clients.
+//
+// Parameters:
+//   - opts: options customizing the interceptor
+//
+// Returns:
+//   - *Interceptor: the interceptor, to be passed to connect.WithInterceptors
+func NewInterceptor(opts ...Option) *Interceptor {
+	interceptor := &Interceptor{}
+	for _, opt := range opts {
+		opt(interceptor)
+	}
+
+	return interceptor
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+
+		if req.Spec().IsClient {
+			return res, FromConnectError(err)
+		}
+
+		return res, i.handlerError(ctx, err)
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return &clientConn{StreamingClientConn: next(ctx, spec)}
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := next(ctx, conn); err != nil {
+			return i.handlerError(ctx, err)
+		}
+
+		return nil
+	}
+}
+
+func (i *Interceptor) handlerError(ctx context.Context, err error) error {
+	if i.report != nil {
+		_ = i.report(ctx, err)
+	}
+
+	return ToConnectError(err)
+}
+
+// clientConn classifies the errors received on a client stream. io.EOF is returned unchanged,
+// since Connect signals the end of a stream with it.
+type clientConn struct {
+	connect.StreamingClientConn
+}
+
+func (c *clientConn) Receive(msg any) error {
+	if err := c.StreamingClientConn.Receive(msg); err != nil {
+		return FromConnectError(err)
+	}
+
+	return nil
+}
+
+func (c *clientConn) CloseResponse() error {
+	return FromConnectError(c.StreamingClientConn.CloseResponse())
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// FieldContextDeadline is the field holding the deadline of the context passed to WrapCtxf.
+	FieldContextDeadline = "ctx.deadline"
+	// FieldContextError is the field holding the error of the context passed to WrapCtxf, once it is done.
+	FieldContextError = "ctx.error"
+)
+
+type (
+	// ContextExtractor returns the fields to fold into errors wrapped with WrapCtxf, such as trace IDs
+	// or request information stored in the context. It returns nil when the context holds nothing relevant.
+	ContextExtractor func(ctx context.Context) Fields
+)
+
+var (
+	contextExtractorsMu sync.Mutex                         //nolint:gochecknoglobals
+	contextExtractors   atomic.Pointer[[]ContextExtractor] //nolint:gochecknoglobals
+)
+
+// RegisterContextExtractor adds an extractor consulted by WrapCtxf, e.g. datadog.ContextFields for trace IDs
+// and request information. Extractors apply in registration order; later ones override earlier fields.
+//
+// Parameters:
+//   - extractor: the extractor to add; nil is ignored
+func RegisterContextExtractor(extractor ContextExtractor) {
+	if extractor == nil {
+		return
+	}
+
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+
+	var registered []ContextExtractor
+	if current := contextExtractors.Load(); current != nil {
+		registered = slices.Clone(*current)
+	}
+
+	registered = append(registered, extractor)
+	contextExtractors.Store(&registered)
+}
+
+// WrapCtxf wraps err with a formatted description and a call stack, folding into its fields everything known
+// about the request from ctx: the fields of the registered context extractors (trace IDs, request info...),
+// the active feature flags (see RegisterFlagProvider), the context deadline and, once the context is done, its
+// error. It is meant as the primary wrapping API wherever a context is available, so enrichment does not depend
+// on every caller remembering separate helpers.
+//
+// Parameters:
+//   - ctx: the context of the failing operation
+//   - err: the error to wrap
+//   - format: a format string for the description
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil
+func WrapCtxf(ctx context.Context, err error, format string, args ...any) error {
+	if err == nil {
+		recordNilWrap("WrapCtxf", format, 0)
+
+		return nil
+	}
+
+	description, argFields := formatDescription(format, args)
+
+	fields := contextFields(ctx)
+	if len(argFields) > 0 && fields == nil {
+		fields = make(Fields, len(argFields))
+	}
+
+	for key, value := range argFields {
+		fields[key] = value
+	}
+
+	return track(&Error{
+		Description: description,
+		stack:       stackFor(err, 0),
+		error:       err,
+		fields:      fields,
+	})
+}
+
+func contextFields(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields Fields
+
+	set := func(key string, value any) {
+		if fields == nil {
+			fields = make(Fields)
+		}
+
+		fields[key] = value
+	}
+
+	if extractors := contextExtractors.Load(); extractors != nil {
+		for _, extractor := range *extractors {
+			for key, value := range extractor(ctx) {
+				set(key, value)
+			}
+		}
+	}
+
+	for key, value := range flagFields(ctx) {
+		set(key, value)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		set(FieldContextDeadline, deadline.Format(time.RFC3339Nano))
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		set(FieldContextError, ctxErr.Error())
+	}
+
+	return fields
+}
@@ -0,0 +1,38 @@
+package datadog
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ceearrashee/errors"
+)
+
+type (
+	// AttachmentStore uploads an error attachment to external storage and returns the URL referencing it.
+	AttachmentStore func(ctx context.Context, attachment errors.Attachment) (string, error)
+)
+
+var attachmentStore atomic.Pointer[AttachmentStore] //nolint:gochecknoglobals
+
+// SetAttachmentStore configures where HandleError uploads error attachments; the span then references each one
+// through an "error.attachment.<name>" tag holding its URL. Without a store, attachments are recorded as span events.
+//
+// Parameters:
+//   - store: the upload function; nil removes the configured store
+func SetAttachmentStore(store AttachmentStore) {
+	if store == nil {
+		attachmentStore.Store(nil)
+
+		return
+	}
+
+	attachmentStore.Store(&store)
+}
+
+func loadAttachmentStore() AttachmentStore {
+	if store := attachmentStore.Load(); store != nil {
+		return *store
+	}
+
+	return nil
+}
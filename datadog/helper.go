@@ -2,10 +2,14 @@ package datadog
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/ceearrashee/errors"
@@ -28,6 +32,8 @@ type (
 		Headers map[string]string `json:"headers,omitempty"`
 		// Body contains the HTTP request body, which may include textual or JSON data.
 		Body string `json:"body,omitempty"`
+		// RequestIDs contain the request IDs by header name (X-Request-ID, traceparent...), see SetRequestIDHeaders.
+		RequestIDs map[string]string `json:"request_ids,omitempty"`
 	}
 	// Context key type to avoid collisions.
 	ctxKey int
@@ -49,7 +55,56 @@ func WithRequest(ctx context.Context, info RequestInfo) context.Context {
 	return context.WithValue(ctx, requestInfoKey, info)
 }
 
+// ContextFields returns the trace and span IDs of the span in ctx together with the method, URI and request IDs
+// of the RequestInfo attached with WithRequest. Register it once with errors.RegisterContextExtractor so every
+// errors.WrapCtxf call carries them.
+//
+// Parameters:
+//   - ctx: the context holding the span and request information
+//
+// Returns:
+//   - errors.Fields: the fields, or nil if the context holds neither
+func ContextFields(ctx context.Context) errors.Fields {
+	var fields errors.Fields
+
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		fields = errors.Fields{
+			ext.LogKeyTraceID: strconv.FormatUint(span.Context().TraceIDLower(), 10),
+			ext.LogKeySpanID:  strconv.FormatUint(span.Context().SpanID(), 10),
+		}
+	}
+
+	if ri, ok := ctx.Value(requestInfoKey).(RequestInfo); ok {
+		if fields == nil {
+			fields = make(errors.Fields, 2) //nolint:mnd
+		}
+
+		if ri.Method != "" {
+			fields[errors.FieldHTTPMethod] = ri.Method
+		}
+
+		if ri.URI != "" {
+			fields[errors.FieldHTTPURL] = ri.URI
+		}
+
+		for name, id := range requestIDs(ri) {
+			fields[FieldRequestIDPrefix+strings.ToLower(name)] = id
+		}
+	}
+
+	return fields
+}
+
 // HandleError reports an error to a tracing span, adding detailed context and stack trace.
+// Errors logged below slog.LevelError by the log policy (see errors.LogLevelOf), such as expected
+// client errors, are reported as warnings like HandleWarning, so they do not inflate error rates.
+// Warnings attached with errors.WithWarnings are recorded as "warning" span events. The error.stack tag lists the
+// application frames first (see errors.FrameOrigin), so they survive the tag limits (see SetTagLimits).
+// Past the report budget (see errors.NewReportBudget), the report is degraded to the message, type, reference
+// code and level of the error, and the span is tagged with error.report_degraded; so are the reports beyond the
+// quota of their tenant (see errors.AllowTenantReport), additionally tagged with error.tenant_throttled.
+// The budget is checked between the tagging steps and bounds attachment uploads, but a tracer call that blocks
+// runs to completion, so the report may still exceed it by the duration of that call.
 //
 // Parameters:
 //   - ctx: the context containing the tracing information
@@ -63,6 +118,32 @@ func HandleError(ctx context.Context, err error) error {
 		return nil
 	}
 
+	return report(ctx, err, errors.LogLevelOf(err) < slog.LevelError)
+}
+
+// HandleWarning reports an error to a tracing span as a "warning" span event carrying its message, type and stack,
+// with the same context tags as HandleError, but without marking the span as failed.
+//
+// Parameters:
+//   - ctx: the context containing the tracing information
+//   - err: the error to report
+func HandleWarning(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return report(ctx, err, true)
+}
+
+func report(ctx context.Context, err error, warning bool) error {
+	budget := errors.NewReportBudget(ctx)
+	newSite := !errors.IsKnownSite(err)
+
+	errors.Observe(err)
+
+	burst := errors.IsBursting(err)
+	throttled := !errors.AllowTenantReport(err)
+
 	span, _ := tracer.SpanFromContext(ctx)
 	if span == nil {
 		return nil
@@ -70,34 +151,211 @@ func HandleError(ctx context.Context, err error) error {
 
 	defer span.Finish()
 
-	var (
-		typedErrorPtr *errors.Error
-		typedError    errors.Error
-		stack         string
-	)
+	var frames []string
+
+	// Past the report budget or the quota of the tenant, the stack is skipped: only the compact report below is sent.
+	if !throttled && !budget.Exceeded() {
+		if origin := errors.FindOriginalErrorWithStack(err); origin != nil {
+			frames = appFirstStack(origin.Frames())
+		}
+	}
 
-	if errors.As(err, &typedErrorPtr) {
-		stack = strings.Join(typedErrorPtr.GetCallStack(), "\n")
-	} else if errors.As(err, &typedError) {
-		stack = strings.Join(typedError.GetCallStack(), "\n")
+	switch {
+	case errors.OnlyWarnings(err):
+		// Each warning gets its own event below.
+	case warning:
+		addWarningEvent(span, err, frames)
+	default:
+		// Mark span as error with details compatible with DataDog UI.
+		span.SetTag(ext.Error, true)
+		span.SetTag(ext.ErrorMsg, errors.ScrubMessage(err.Error()))
+		span.SetTag(ext.ErrorType, fmt.Sprintf("%T", err))
+
+		setSpanStack(span, frames)
 	}
 
-	// Build application stack skipping helper frames.
+	span.SetTag("error.reference_code", errors.AutoCode(err))
+	span.SetTag("error.level", errors.LogLevelOf(err).String())
 
-	// Mark span as error with details compatible with DataDog UI.
-	span.SetTag(ext.Error, true)
-	span.SetTag(ext.ErrorMsg, err.Error())
-	span.SetTag(ext.ErrorType, fmt.Sprintf("%T", err))
+	if newSite {
+		span.SetTag("error.new_site", true)
+	}
+
+	if burst {
+		span.SetTag("error.burst", true)
+	}
 
-	if stack != "" {
-		span.SetTag(ext.ErrorStack, stack)
+	if tenant := errors.TenantOf(err); tenant != "" {
+		span.SetTag("error.tenant", tenant)
+	}
+
+	if throttled {
+		span.SetTag("error.tenant_throttled", true)
+		span.SetTag("error.report_degraded", true)
+
+		return nil
+	}
+
+	// The details are added while the report budget allows, degrading to the compact report above otherwise.
+	details := []func(){
+		func() {
+			for _, attached := range errors.WarningsOf(err) {
+				addWarningEvent(span, attached, errors.FindOriginalErrorWithStack(attached).GetCallStack())
+			}
+		},
+		func() {
+			if frame, ok := errors.AppFrame(err); ok {
+				span.SetTag("error.app_frame", fmt.Sprintf("%s\n\t%s:%d", frame.Function, frame.File, frame.Line))
+			}
+		},
+		func() { setSpanStructuredData(span, err) },
+		func() { setSpanNamedCauses(span, err) },
+		func() { setSpanAttachments(ctx, budget, span, err) },
+		func() { setSpanRequestInfo(ctx, span) },
+		func() { setSpanFlags(ctx, span, err) },
 	}
 
-	setSpanRequestInfo(ctx, span)
+	for _, detail := range details {
+		if budget.Exceeded() {
+			span.SetTag("error.report_degraded", true)
+
+			break
+		}
+
+		detail()
+	}
 
 	return nil
 }
 
+// appFirstStack renders the frames of a stack with the application frames first, then those of dependencies and of
+// the standard library, keeping the call order within each origin, so the frames pointing at the failing code are
+// the ones kept when the stack is cut to fit the span tag.
+func appFirstStack(frames []errors.Frame) []string {
+	slices.SortStableFunc(frames, func(a, b errors.Frame) int { return cmp.Compare(a.Origin, b.Origin) })
+
+	rendered := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		rendered = append(rendered, fmt.Sprintf("%s\n\t%s:%d", frame.Function, frame.File, frame.Line))
+	}
+
+	return rendered
+}
+
+// addWarningEvent records err as a "warning" span event carrying its message, type and stack.
+func addWarningEvent(span *tracer.Span, err error, frames []string) {
+	attributes := map[string]any{
+		"message": errors.ScrubMessage(err.Error()),
+		"type":    fmt.Sprintf("%T", err),
+	}
+
+	if len(frames) > 0 {
+		attributes["stack"] = strings.Join(frames, "\n")
+	}
+
+	span.AddEvent("warning", tracer.WithSpanEventAttributes(attributes))
+}
+
+func setSpanStructuredData(span *tracer.Span, err error) {
+	if instanceID := errors.InstanceID(err); instanceID != "" {
+		span.SetTag("error.instance_id", instanceID)
+	}
+
+	if template := errors.TemplateOf(err); template != "" {
+		span.SetTag("error.template", template)
+	}
+
+	for key, value := range errors.ScrubFields(errors.FieldsOf(err)) {
+		span.SetTag("error.fields."+key, value)
+	}
+
+	for key, value := range errors.EnvSnapshot(err) {
+		span.SetTag("error.fields."+key, value)
+	}
+
+	if owner := errors.OwnerOf(err); owner != "" {
+		span.SetTag("error.owner", owner)
+	}
+
+	if upstream, ok := errors.UpstreamOf(err); ok {
+		span.SetTag(ext.PeerService, upstream.System)
+
+		if upstream.Endpoint != "" {
+			span.SetTag("error.upstream.endpoint", upstream.Endpoint)
+		}
+	}
+
+	if tags := errors.TagsOf(err); len(tags) > 0 {
+		span.SetTag("error.tags", strings.Join(tags, ","))
+	}
+}
+
+// setSpanFlags tags the feature flags active at report time that the error did not record when it was wrapped,
+// scrubbed like the other fields.
+func setSpanFlags(ctx context.Context, span *tracer.Span, err error) {
+	recorded := errors.FieldsOf(err)
+	flags := make(errors.Fields)
+
+	for name, value := range errors.ActiveFlags(ctx) {
+		if _, ok := recorded[errors.FieldFlagPrefix+name]; !ok {
+			flags[errors.FieldFlagPrefix+name] = value
+		}
+	}
+
+	for key, value := range errors.ScrubFields(flags) {
+		span.SetTag("error.fields."+key, value)
+	}
+}
+
+func setSpanNamedCauses(span *tracer.Span, err error) {
+	for name, cause := range errors.NamedCauses(err) {
+		span.SetTag("error.cause."+name, errors.ScrubMessage(cause.Error()))
+
+		if frameworkErr := errors.FindOriginalErrorWithStack(cause); frameworkErr != nil {
+			// Cause stacks keep their leading frames only, so the agent never cuts them mid-frame.
+			if chunks := splitFrames(frameworkErr.GetCallStack(), currentTagLimits().MaxValueLength); len(chunks) > 0 {
+				span.SetTag("error.cause."+name+".stack", chunks[0])
+			}
+		}
+	}
+}
+
+// setSpanAttachments references uploaded attachments by URL, or records them as span events when no store
+// is configured, keeping oversized payloads out of span tags. Uploads are bounded by the report budget.
+func setSpanAttachments(ctx context.Context, budget errors.ReportBudget, span *tracer.Span, err error) {
+	store := loadAttachmentStore()
+
+	ctx, cancel := budget.Context(ctx)
+	defer cancel()
+
+	for _, attachment := range errors.AttachmentsOf(err) {
+		attachment = errors.ScrubAttachment(attachment)
+
+		if store != nil {
+			url, uploadErr := store(ctx, attachment)
+			if uploadErr == nil {
+				span.SetTag("error.attachment."+attachment.Name, url)
+
+				continue
+			}
+
+			span.SetTag("error.attachment."+attachment.Name+".upload_error", uploadErr.Error())
+		}
+
+		attributes := map[string]any{
+			"name":         attachment.Name,
+			"content_type": attachment.ContentType,
+			"size":         len(attachment.Data),
+		}
+
+		if strings.HasPrefix(attachment.ContentType, "text/") || strings.HasSuffix(attachment.ContentType, "json") {
+			attributes["data"] = string(attachment.Data)
+		}
+
+		span.AddEvent("attachment", tracer.WithSpanEventAttributes(attributes))
+	}
+}
+
 func setSpanRequestInfo(ctx context.Context, span *tracer.Span) {
 	// Attach HTTP info if present in ctx.
 	v := ctx.Value(requestInfoKey)
@@ -110,6 +368,8 @@ func setSpanRequestInfo(ctx context.Context, span *tracer.Span) {
 		return
 	}
 
+	ri = scrubRequestInfo(ri)
+
 	if ri.Method != "" {
 		span.SetTag(ext.HTTPMethod, ri.Method)
 	}
@@ -124,6 +384,32 @@ func setSpanRequestInfo(ctx context.Context, span *tracer.Span) {
 	}
 }
 
+// scrubRequestInfo applies the scrubbers registered with errors.RegisterScrubber to the request data,
+// under the "request.method", "request.uri", "request.header.<name>" and "request.body" keys.
+func scrubRequestInfo(ri RequestInfo) RequestInfo {
+	scrubbed := RequestInfo{
+		Method: errors.ScrubString("request.method", ri.Method),
+		URI:    errors.ScrubString("request.uri", ri.URI),
+		Body:   errors.ScrubString("request.body", ri.Body),
+	}
+
+	if len(ri.Headers) > 0 {
+		scrubbed.Headers = make(map[string]string, len(ri.Headers))
+		for name, value := range ri.Headers {
+			scrubbed.Headers[name] = errors.ScrubString("request.header."+name, value)
+		}
+	}
+
+	if len(ri.RequestIDs) > 0 {
+		scrubbed.RequestIDs = make(map[string]string, len(ri.RequestIDs))
+		for name, value := range ri.RequestIDs {
+			scrubbed.RequestIDs[name] = errors.ScrubString("request.header."+name, value)
+		}
+	}
+
+	return scrubbed
+}
+
 func compactDetails(ri RequestInfo) string {
 	extraData := make(map[string]any)
 	if ri.Method != "" {
@@ -138,8 +424,12 @@ func compactDetails(ri RequestInfo) string {
 		extraData["headers"] = ri.Headers
 	}
 
+	if ids := requestIDs(ri); len(ids) > 0 {
+		extraData["request_ids"] = ids
+	}
+
 	if ri.Body != "" {
-		// Beware of PII: caller should already have scrubbed sensitive data.
+		// Beware of PII: the body is only scrubbed by the scrubbers registered with errors.RegisterScrubber.
 		extraData["body"] = ri.Body
 	}
 
@@ -0,0 +1,50 @@
+package datadog_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/ext"
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/mocktracer"
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/tracer"
+
+	"github.com/ceearrashee/errors"
+	"github.com/ceearrashee/errors/datadog"
+)
+
+func TestHandleErrorReportsChainsWithoutStack(t *testing.T) {
+	mt := mocktracer.Start()
+	t.Cleanup(mt.Stop)
+
+	cases := map[string]struct {
+		err       error
+		wantStack bool
+	}{
+		"foreign error":  {io.EOF, false},
+		"wrapped error":  {errors.Wrap(io.EOF, "read body"), true},
+		"sentinel error": {errors.ErrInternalServerError, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mt.Reset()
+
+			ctx := tracer.ContextWithSpan(context.Background(), tracer.StartSpan("request"))
+			_ = datadog.HandleError(ctx, tc.err)
+
+			spans := mt.FinishedSpans()
+			if len(spans) != 1 {
+				t.Fatalf("finished %d spans, want 1", len(spans))
+			}
+
+			if got := spans[0].Tag(ext.ErrorMsg); got != tc.err.Error() {
+				t.Fatalf("error.message = %v, want %q", got, tc.err.Error())
+			}
+
+			if _, ok := spans[0].Tags()[ext.ErrorStack]; ok != tc.wantStack {
+				t.Fatalf("error.stack set = %t, want %t", ok, tc.wantStack)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package datadog
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// FieldRequestIDPrefix prefixes the fields holding the request IDs of the RequestInfo, e.g. "request_id.x-request-id".
+const FieldRequestIDPrefix = "request_id."
+
+var requestIDHeaders atomic.Pointer[[]string] //nolint:gochecknoglobals
+
+// DefaultRequestIDHeaders returns the request headers captured as request IDs by default:
+// X-Request-ID, the W3C traceparent and the AWS X-Amzn-Trace-Id.
+//
+// Returns:
+//   - []string: the header names
+func DefaultRequestIDHeaders() []string {
+	return []string{"X-Request-ID", "traceparent", "X-Amzn-Trace-Id"}
+}
+
+// SetRequestIDHeaders replaces the request headers captured as request IDs by RequestInfoFromHTTP,
+// and recognized among the Headers of a RequestInfo.
+//
+// Parameters:
+//   - names: the header names, matched case-insensitively; none disables the capture
+func SetRequestIDHeaders(names ...string) {
+	names = slices.Clone(names)
+	requestIDHeaders.Store(&names)
+}
+
+// RequestInfoFromHTTP builds the RequestInfo of an incoming request: its method, URI and request IDs.
+// Headers and Body are left empty, so sensitive data is only recorded when set explicitly.
+//
+// Parameters:
+//   - r: the incoming request
+//
+// Returns:
+//   - RequestInfo: the request information
+func RequestInfoFromHTTP(r *http.Request) RequestInfo {
+	info := RequestInfo{Method: r.Method, URI: r.URL.RequestURI()}
+
+	for _, name := range currentRequestIDHeaders() {
+		if value := r.Header.Get(name); value != "" {
+			if info.RequestIDs == nil {
+				info.RequestIDs = make(map[string]string)
+			}
+
+			info.RequestIDs[name] = value
+		}
+	}
+
+	return info
+}
+
+// RequestMiddleware attaches the RequestInfoFromHTTP of every request to its context (see WithRequest),
+// so the errors it reports carry the request method, URI and IDs.
+//
+// Parameters:
+//   - next: the handler to wrap
+//
+// Returns:
+//   - http.Handler: the wrapping handler
+func RequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithRequest(r.Context(), RequestInfoFromHTTP(r))))
+	})
+}
+
+func currentRequestIDHeaders() []string {
+	if names := requestIDHeaders.Load(); names != nil {
+		return *names
+	}
+
+	return DefaultRequestIDHeaders()
+}
+
+// requestIDs returns the request IDs of the RequestInfo, including the configured headers found among its Headers.
+func requestIDs(ri RequestInfo) map[string]string {
+	ids := maps.Clone(ri.RequestIDs)
+
+	for name, value := range ri.Headers {
+		if value == "" || !slices.ContainsFunc(currentRequestIDHeaders(), func(n string) bool { return strings.EqualFold(n, name) }) {
+			continue
+		}
+
+		if ids == nil {
+			ids = make(map[string]string)
+		}
+
+		if _, exists := ids[name]; !exists {
+			ids[name] = value
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,132 @@
+package datadog
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/ext"
+	"github.com/DataDog/dd-trace-go/v2/ddtrace/tracer"
+)
+
+const (
+	// DefaultMaxTagValueLength is the length in bytes above which the Datadog agent truncates span tag values.
+	DefaultMaxTagValueLength = 25000
+	// DefaultMaxStackTags is the default maximum number of "error.stack.<n>" tags an oversized stack continues in.
+	DefaultMaxStackTags = 8
+)
+
+const (
+	// StackOverflowSplit continues oversized stacks across "error.stack.1" to "error.stack.<n>" tags.
+	StackOverflowSplit StackOverflow = iota
+	// StackOverflowEvent records oversized stacks in full as an "error.stack" span event.
+	StackOverflowEvent
+)
+
+type (
+	// StackOverflow selects how HandleError reports a stack too long for a single span tag.
+	StackOverflow int
+
+	// TagLimits describes the span tag value limits HandleError stays within, so stacks are never cut mid-frame
+	// by the agent. Zero values fall back to the defaults.
+	TagLimits struct {
+		// MaxValueLength is the maximum length in bytes of a span tag value (DefaultMaxTagValueLength by default).
+		MaxValueLength int
+		// MaxStackTags is the maximum number of "error.stack.<n>" tags a split stack continues in after
+		// "error.stack" (DefaultMaxStackTags by default); frames beyond them are dropped.
+		MaxStackTags int
+		// Overflow selects how oversized stacks are reported (StackOverflowSplit by default).
+		Overflow StackOverflow
+	}
+)
+
+var tagLimits atomic.Pointer[TagLimits] //nolint:gochecknoglobals
+
+// SetTagLimits replaces the span tag value limits applied by HandleError.
+//
+// Parameters:
+//   - limits: the limits to apply
+func SetTagLimits(limits TagLimits) {
+	if limits.MaxValueLength <= 0 {
+		limits.MaxValueLength = DefaultMaxTagValueLength
+	}
+
+	if limits.MaxStackTags <= 0 {
+		limits.MaxStackTags = DefaultMaxStackTags
+	}
+
+	tagLimits.Store(&limits)
+}
+
+func currentTagLimits() TagLimits {
+	if limits := tagLimits.Load(); limits != nil {
+		return *limits
+	}
+
+	return TagLimits{MaxValueLength: DefaultMaxTagValueLength, MaxStackTags: DefaultMaxStackTags}
+}
+
+// setSpanStack tags the span with the frames of a stack within the tag limits. The "error.stack" tag always holds
+// the leading frames fitting in a single tag; when the stack does not fit, "error.stack_truncated" is set and the rest
+// continues in "error.stack.1" to "error.stack.<n>" tags or is recorded in full as a span event, depending on the
+// overflow mode.
+func setSpanStack(span *tracer.Span, frames []string) {
+	limits := currentTagLimits()
+
+	chunks := splitFrames(frames, limits.MaxValueLength)
+	if len(chunks) == 0 {
+		return
+	}
+
+	span.SetTag(ext.ErrorStack, chunks[0])
+
+	if len(chunks) == 1 {
+		return
+	}
+
+	span.SetTag("error.stack_truncated", true)
+
+	if limits.Overflow == StackOverflowEvent {
+		span.AddEvent(ext.ErrorStack, tracer.WithSpanEventAttributes(map[string]any{
+			"stack": strings.Join(frames, "\n"),
+		}))
+
+		return
+	}
+
+	for i, chunk := range chunks[1:min(len(chunks), limits.MaxStackTags+1)] {
+		span.SetTag(ext.ErrorStack+"."+strconv.Itoa(i+1), chunk)
+	}
+}
+
+// splitFrames groups newline-joined frames into chunks of at most limit bytes, never splitting a frame
+// unless it is longer than the limit on its own.
+func splitFrames(frames []string, limit int) []string {
+	var (
+		chunks  []string
+		current strings.Builder
+	)
+
+	for _, frame := range frames {
+		if len(frame) > limit {
+			frame = frame[:limit]
+		}
+
+		if current.Len() > 0 && current.Len()+1+len(frame) > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+
+		if current.Len() > 0 {
+			current.WriteByte('\n')
+		}
+
+		current.WriteString(frame)
+	}
+
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+
+	return chunks
+}
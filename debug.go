@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultDebugCapacity is the number of creation records kept by EnableDebug when no capacity is given.
+const DefaultDebugCapacity = 1024
+
+type (
+	// DebugRecord describes the creation of a single error while debug mode is enabled.
+	DebugRecord struct {
+		Time     time.Time `json:"time"`
+		Function string    `json:"function,omitempty"`
+		File     string    `json:"file,omitempty"`
+		Line     int       `json:"line,omitempty"`
+		Message  string    `json:"message"`
+	}
+
+	// debugRing is a fixed-size ring buffer of creation records.
+	debugRing struct {
+		mu      sync.Mutex
+		records []DebugRecord
+		next    int
+		full    bool
+
+		// nilWraps counts the wrapping calls with a nil error by call site, see NilWraps.
+		nilWraps map[string]*NilWrap
+	}
+)
+
+var debugLog atomic.Pointer[debugRing] //nolint:gochecknoglobals
+
+// EnableDebug turns on the debug mode, recording the site, time and message of every error created
+// by this package into a ring buffer, to diagnose where an error originated without a debugger, and counting
+// the call sites wrapping a nil error (see NilWraps). Calling it again resets the buffer and the counters.
+//
+// Parameters:
+//   - capacity: the number of most recent records to keep; zero or less uses DefaultDebugCapacity
+func EnableDebug(capacity int) {
+	if capacity <= 0 {
+		capacity = DefaultDebugCapacity
+	}
+
+	debugLog.Store(&debugRing{records: make([]DebugRecord, capacity), nilWraps: make(map[string]*NilWrap)})
+}
+
+// DisableDebug turns off the debug mode and discards the recorded history.
+func DisableDebug() {
+	debugLog.Store(nil)
+}
+
+// DebugDump returns the recorded error creations, oldest first.
+//
+// Returns:
+//   - []DebugRecord: the recorded creations, or nil if debug mode is disabled
+func DebugDump() []DebugRecord {
+	ring := debugLog.Load()
+	if ring == nil {
+		return nil
+	}
+
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+
+	if !ring.full {
+		return append([]DebugRecord(nil), ring.records[:ring.next]...)
+	}
+
+	return append(append([]DebugRecord(nil), ring.records[ring.next:]...), ring.records[:ring.next]...)
+}
+
+// DebugHandler returns an HTTP handler serving DebugDump as JSON.
+//
+// Returns:
+//   - http.Handler: the debug handler
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		_ = json.NewEncoder(w).Encode(DebugDump()) //nolint:errcheck,errchkjson
+	})
+}
+
+// track assigns an instance ID to e when instance IDs are enabled, inherits the fields of the wrapped chain
+// in eager field merge mode, records its creation when debug mode is enabled and returns e. It must be called
+// directly by the constructor, so the creation site is the constructor's caller.
+func track(e *Error) *Error {
+	if instanceIDs.Load() {
+		e.instanceID = newID()
+	}
+
+	inheritFields(e)
+
+	ring := debugLog.Load()
+	if ring == nil {
+		return e
+	}
+
+	record := DebugRecord{Time: now(), Message: e.Error()}
+
+	if e.stack != nil && len(*e.stack) > 0 {
+		frame, _ := runtime.CallersFrames(*e.stack).Next()
+		record.Function, record.File, record.Line = frame.Function, frame.File, frame.Line
+	} else if pcs := make([]uintptr, 8); runtime.Callers(3, pcs) > 0 { //nolint:mnd
+		// CallersFrames, unlike FuncForPC, resolves the right function when the caller was inlined.
+		frame, _ := runtime.CallersFrames(pcs).Next()
+		record.Function, record.File, record.Line = frame.Function, frame.File, frame.Line
+	}
+
+	ring.mu.Lock()
+	ring.records[ring.next] = record
+	ring.next = (ring.next + 1) % len(ring.records)
+	ring.full = ring.full || ring.next == 0
+	ring.mu.Unlock()
+
+	return e
+}
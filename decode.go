@@ -0,0 +1,158 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FieldDecodeLine is the field holding the 1-based line of the input where decoding failed.
+	FieldDecodeLine = "decode.line"
+	// FieldDecodeColumn is the field holding the 1-based column of the input where decoding failed.
+	FieldDecodeColumn = "decode.column"
+	// FieldDecodeField is the field holding the path of the field that could not be decoded, e.g. "items.0.price".
+	FieldDecodeField = "decode.field"
+	// FieldDecodeExpected is the field holding the Go type the value could not be decoded into.
+	FieldDecodeExpected = "decode.expected"
+)
+
+//nolint:gochecknoglobals
+var (
+	yamlLinePattern    = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	jsonUnknownPattern = regexp.MustCompile(`^json: unknown field "(.*)"$`)
+)
+
+// DecodeError converts a failure of decoding JSON or YAML input into an ErrValidation-classified error with a
+// user-actionable description and the position of the failure in the FieldDecodeLine, FieldDecodeColumn,
+// FieldDecodeField and FieldDecodeExpected fields. It understands json.SyntaxError, json.UnmarshalTypeError,
+// unknown fields rejected by json.Decoder.DisallowUnknownFields, truncated or empty input, and the line-prefixed
+// errors of gopkg.in/yaml.v3. Other errors, such as json.InvalidUnmarshalError, are returned unchanged.
+//
+// Parameters:
+//   - err: the error returned by the decoder
+//   - input: the decoded input, used to turn byte offsets into lines and columns; may be nil
+//
+// Returns:
+//   - error: the classified error, err unchanged if it is not a decoding failure, or nil if err is nil
+func DecodeError(err error, input []byte) error {
+	if err == nil {
+		return nil
+	}
+
+	description, fields, ok := describeDecodeError(err, input)
+	if !ok {
+		return err
+	}
+
+	cause := fmt.Errorf("%w: %w", ErrValidation, err)
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(cause, 0),
+		fields:      fields,
+		error:       cause,
+	})
+}
+
+func describeDecodeError(err error, input []byte) (string, Fields, bool) {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case As(err, &syntaxErr):
+		fields := positionFields(input, syntaxErr.Offset)
+
+		return "invalid JSON" + positionSuffix(fields) + ": " + syntaxErr.Error(), fields, true
+	case As(err, &typeErr):
+		fields := positionFields(input, typeErr.Offset)
+		fields[FieldDecodeExpected] = typeErr.Type.String()
+
+		if typeErr.Field == "" {
+			return fmt.Sprintf("invalid JSON value%s: expected %s, got %s", positionSuffix(fields), typeErr.Type, typeErr.Value),
+				fields, true
+		}
+
+		fields[FieldDecodeField] = typeErr.Field
+
+		return fmt.Sprintf("invalid value for field %q%s: expected %s, got %s",
+			typeErr.Field, positionSuffix(fields), typeErr.Type, typeErr.Value), fields, true
+	case Is(err, io.ErrUnexpectedEOF):
+		return "unexpected end of input", Fields{}, true
+	case Is(err, io.EOF):
+		return "empty input", Fields{}, true
+	}
+
+	if match := jsonUnknownPattern.FindStringSubmatch(err.Error()); match != nil {
+		return fmt.Sprintf("unknown field %q", match[1]), Fields{FieldDecodeField: match[1]}, true
+	}
+
+	return describeYAMLError(err)
+}
+
+// describeYAMLError parses the messages of gopkg.in/yaml.v3, which carry positions only in their text:
+// "yaml: line 3: did not find expected key" and "yaml: unmarshal errors:\n  line 2: cannot unmarshal ...".
+func describeYAMLError(err error) (string, Fields, bool) {
+	message, isYAML := strings.CutPrefix(err.Error(), "yaml: ")
+	if !isYAML {
+		return "", nil, false
+	}
+
+	lines := strings.Split(strings.TrimPrefix(message, "unmarshal errors:\n"), "\n")
+	descriptions := make([]string, 0, len(lines))
+	fields := Fields{}
+
+	for _, text := range lines {
+		text = strings.TrimSpace(text)
+
+		match := yamlLinePattern.FindStringSubmatch(text)
+		if match == nil {
+			descriptions = append(descriptions, text)
+
+			continue
+		}
+
+		if _, ok := fields[FieldDecodeLine]; !ok {
+			line, _ := strconv.Atoi(match[1]) //nolint:errcheck
+			fields[FieldDecodeLine] = line
+		}
+
+		descriptions = append(descriptions, "line "+match[1]+": "+match[2])
+	}
+
+	return "invalid YAML: " + strings.Join(descriptions, "; "), fields, true
+}
+
+// positionFields turns the byte offset reported by encoding/json into a line and column of input.
+func positionFields(input []byte, offset int64) Fields {
+	fields := Fields{}
+	if input == nil || offset <= 0 || offset > int64(len(input)) {
+		return fields
+	}
+
+	consumed := input[:offset]
+	fields[FieldDecodeLine] = bytes.Count(consumed, []byte("\n")) + 1
+	fields[FieldDecodeColumn] = len(consumed) - bytes.LastIndexByte(consumed, '\n') - 1
+
+	return fields
+}
+
+func positionSuffix(fields Fields) string {
+	line, hasLine := fields[FieldDecodeLine]
+	column, hasColumn := fields[FieldDecodeColumn]
+
+	switch {
+	case hasLine && hasColumn:
+		return fmt.Sprintf(" at line %v, column %v", line, column)
+	case hasLine:
+		return fmt.Sprintf(" at line %v", line)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// FieldEnvPrefix prefixes the fields holding environment variables, e.g. "env.REGION".
+const FieldEnvPrefix = "env."
+
+var reportEnv atomic.Pointer[[]string] //nolint:gochecknoglobals
+
+// WithEnv records the values of the named environment variables into fields when the option is applied,
+// reading the environment at each application rather than when the option is built, so environment-specific
+// failures can be diagnosed from a single report. Unset variables are skipped and values go through the
+// registered scrubbers like every field, so only allowlist variables that are safe to report.
+//
+// Parameters:
+//   - names: the allowlisted environment variables, e.g. "REGION", "POD_NAME"
+//
+// Returns:
+//   - Option: the option recording the variables in FieldEnvPrefix fields
+func WithEnv(names ...string) Option {
+	names = append([]string(nil), names...)
+
+	return func(e *Error) {
+		WithFields(envFields(names))(e)
+	}
+}
+
+// SetReportEnv sets the environment variables snapshotted when errors are reported (see EnvSnapshot),
+// in addition to those recorded with WithEnv when the errors were created.
+//
+// Parameters:
+//   - names: the allowlisted environment variables; none disables the snapshot
+func SetReportEnv(names ...string) {
+	allowlist := append([]string(nil), names...)
+	reportEnv.Store(&allowlist)
+}
+
+// EnvSnapshot returns the current values of the environment variables set with SetReportEnv,
+// for reporters to attach at report time.
+//
+// Parameters:
+//   - err: the reported error; variables it recorded with WithEnv are not repeated
+//
+// Returns:
+//   - Fields: the FieldEnvPrefix fields of the variables, or nil if none is set
+func EnvSnapshot(err error) Fields {
+	names := reportEnv.Load()
+	if names == nil {
+		return nil
+	}
+
+	snapshot := envFields(*names)
+	for key := range FieldsOf(err) {
+		delete(snapshot, key)
+	}
+
+	if len(snapshot) == 0 {
+		return nil
+	}
+
+	return ScrubFields(snapshot)
+}
+
+func envFields(names []string) Fields {
+	fields := make(Fields, len(names))
+
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			fields[FieldEnvPrefix+name] = value
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// HeaderErrorOrigin is the HTTP response header carrying the chain of services an error went through,
+	// starting with the service where it originated, e.g. "payments,billing".
+	HeaderErrorOrigin = "X-Error-Origin"
+	// FieldErrorOrigin is the field holding the origin chain received from an upstream response, in header format.
+	FieldErrorOrigin = "error.origin"
+)
+
+var serviceName atomic.Pointer[string] //nolint:gochecknoglobals
+
+// SetServiceName sets the name this service appends to the origin chain of the errors it responds with
+// (see SetErrorOriginHeader).
+//
+// Parameters:
+//   - name: the service name; empty disables appending
+func SetServiceName(name string) {
+	serviceName.Store(&name)
+}
+
+// ErrorOriginOf returns the chain of services the error went through before reaching this service,
+// as received in the X-Error-Origin header of an upstream response (see WrapHTTPResponse).
+//
+// Parameters:
+//   - err: the error to inspect
+//
+// Returns:
+//   - []string: the service names, starting with the one where the error originated, or nil if unknown
+func ErrorOriginOf(err error) []string {
+	origin, _ := FieldsOf(err)[FieldErrorOrigin].(string)
+
+	return parseErrorOrigin(origin)
+}
+
+// SetErrorOriginHeader sets the X-Error-Origin header of a response failing with err: the origin chain received
+// from upstream, if any, followed by this service name (see SetServiceName). Services then identify which downstream
+// hop actually failed when an error surfaces at the edge.
+//
+// Parameters:
+//   - header: the response headers to set, typically w.Header()
+//   - err: the error the response reports; if nil, the header is left untouched
+func SetErrorOriginHeader(header http.Header, err error) {
+	if err == nil {
+		return
+	}
+
+	chain := ErrorOriginOf(err)
+	if name := serviceName.Load(); name != nil && *name != "" {
+		chain = append(chain, *name)
+	}
+
+	if len(chain) > 0 {
+		header.Set(HeaderErrorOrigin, strings.Join(chain, ","))
+	}
+}
+
+func parseErrorOrigin(value string) []string {
+	var chain []string
+
+	for service := range strings.SplitSeq(value, ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			chain = append(chain, service)
+		}
+	}
+
+	return chain
+}
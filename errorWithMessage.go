@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"runtime"
+	"slices"
 
 	"github.com/samber/lo"
 )
@@ -14,6 +15,20 @@ type (
 		Description string
 		error       error
 		stack       *Stack
+		causes      map[string]error
+		template    string
+		fields      Fields
+		attachments []Attachment
+		tags        []string
+		code        ErrorCode
+		upstream    *Upstream
+		instanceID  string
+		handoff     handoffKind
+		wire        *wireRecord
+		codePrefix  string
+		warnings    Warnings
+		// fieldsMerged reports that fields already hold the fields of the whole wrapped chain, see FieldMergeEager.
+		fieldsMerged bool
 	}
 
 	// Stack represents a slice of uintptrs, typically used to store function call stack pointers.
@@ -21,13 +36,37 @@ type (
 )
 
 // Format customizes the formatted output of an Error instance.
+// The %+v verb renders the whole chain, its attempt and duration if recorded, followed by the call stack
+// (or the stitched stacks of each goroutine after a Handoff), while any other verb writes the description.
 //
 // Parameters:
 //   - f: the formatter state used for custom formatting
-//   - _: the rune specifying the format verb (unused)
+//   - verb: the rune specifying the format verb
 //
-// Returns: none (writes the formatted description to f)
-func (e *Error) Format(f fmt.State, _ rune) {
+// Returns: none (writes the formatted output to f)
+func (e *Error) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		_, _ = fmt.Fprint(f, Render(e), attemptSummary(e)) //nolint:errcheck,revive
+
+		if writeHandoffStacks(f, e) {
+			return
+		}
+
+		if frameworkErr := FindOriginalErrorWithStack(e); frameworkErr != nil {
+			for _, frame := range frameworkErr.GetCallStack() {
+				_, _ = fmt.Fprintf(f, "\n%s", frame) //nolint:errcheck,revive
+			}
+		}
+
+		return
+	}
+
+	if lazy, ok := e.error.(*lazyMessage); ok && e.Description == "" { //nolint:errorlint
+		_, _ = fmt.Fprintf(f, "%s", lazy.Error()) //nolint:errcheck,revive
+
+		return
+	}
+
 	_, _ = fmt.Fprintf(f, "%s", e.Description) //nolint:errcheck,revive
 }
 
@@ -40,9 +79,12 @@ func (e *Error) Format(f fmt.State, _ rune) {
 // Returns:
 //   - *Error: a pointer to the newly created Error instance with the formatted description set.
 func Newf(formatedDescription string, args ...any) *Error {
-	return &Error{
-		Description: fmt.Sprintf(formatedDescription, args...),
-	}
+	description, fields := formatDescription(formatedDescription, args)
+
+	return track(&Error{
+		Description: description,
+		fields:      fields,
+	})
 }
 
 // Error returns the error message, combining the description and underlying error if present.
@@ -50,11 +92,7 @@ func Newf(formatedDescription string, args ...any) *Error {
 // Returns:
 //   - string: the error message, formatted as a string.
 func (e *Error) Error() string {
-	if e.error == nil {
-		return e.Description
-	}
-
-	return fmt.Sprintf("%s: %s", e.Description, e.error.Error())
+	return renderChain(e, currentRenderConfig())
 }
 
 // Message returns the description if set; otherwise, it returns the underlying error's message.
@@ -95,16 +133,17 @@ func (e *Error) GetOriginalErrorMessage() string {
 	}
 
 	if errToUse == nil {
-		return fmt.Sprintf("%s", e.Description)
+		return e.Description
 	}
 
-	return fmt.Sprintf("%s: %s", e.Description, errToUse.Error())
+	return e.Description + currentRenderConfig().separator + errToUse.Error()
 }
 
 // Wrap adds context to an existing error using the Error's description.
+// An Error without description still wraps err, so a non-nil error is never swallowed.
 //
 // Parameters:
-//   - err: the error to wrap; if nil or no description is provided, returns nil.
+//   - err: the error to wrap; if nil, returns nil.
 //
 // Returns:
 //   - error: a new Error instance incorporating the provided error.
@@ -112,18 +151,20 @@ func (e *Error) GetOriginalErrorMessage() string {
 // Errors:
 //   - None directly, but may wrap any provided error with additional context.
 func (e *Error) Wrap(err error) error {
-	if err == nil || e.Description == "" {
+	if err == nil {
+		recordNilWrap("Error.Wrap", e.Description, 0)
+
 		return nil
 	}
 
-	return &Error{error: err, Description: e.Description, stack: callers()}
+	return track(&Error{error: err, Description: e.Description, stack: stackFor(err, 0)})
 }
 
 // Wrapf formats and wraps an existing error with the Error's description and a custom message.
 //
 // Parameters:
 //   - format: a format string for the error message
-//   - err: the error to wrap; if nil or no description is provided, returns nil
+//   - err: the error to wrap; if nil, returns nil
 //
 // Returns:
 //   - error: a new error combining the format, description, and wrapped error
@@ -131,11 +172,13 @@ func (e *Error) Wrap(err error) error {
 // Errors:
 //   - None directly, but wraps the provided error with formatted context, if present.
 func (e *Error) Wrapf(format string, err error) error {
-	if err == nil || e.Description == "" {
+	if err == nil {
+		recordNilWrap("Error.Wrapf", format, 0)
+
 		return nil
 	}
 
-	er := &Error{error: err, Description: e.Description, stack: callers()}
+	er := track(&Error{error: err, Description: e.Description, stack: stackFor(err, 0)})
 
 	return fmt.Errorf(format+" :%w", er) //nolint:err113
 }
@@ -158,31 +201,37 @@ func (e *Error) GetCallStack() []string {
 		return nil
 	}
 
-	callStackFrames := make([]string, 0, 32)
-	frames := runtime.CallersFrames(*e.stack)
-
-	for {
-		frame, more := frames.Next()
-		if frame.Function == "unknown" {
-			break
-		}
-
-		callStackFrames = append(callStackFrames, fmt.Sprintf("%s\n\t%s:%d", frame.Function, frame.File, frame.Line))
+	return slices.Clone(resolvedFrames.resolve(*e.stack).callStack)
+}
 
-		if !more {
-			break
-		}
+// Frames retrieves the resolved frames of the function call stack associated with the error.
+//
+// Returns:
+//   - []Frame: the frames, in order from most to least recent, or nil if the error carries no stack.
+func (e *Error) Frames() []Frame {
+	if e == nil || e.stack == nil {
+		return nil
 	}
 
-	return callStackFrames
+	return e.stack.Frames()
 }
 
 func callers() *Stack {
+	return callersSkipping(1)
+}
+
+// callersSkipping captures the call stack starting at the caller of the function invoking it,
+// additionally skipping the given number of frames.
+func callersSkipping(skip int) *Stack {
+	if fn := callersFunc.Load(); fn != nil {
+		return (*fn)(skip)
+	}
+
 	const depth = 32
 
 	var pcs [depth]uintptr
 
-	n := runtime.Callers(3, pcs[:]) //nolint:mnd
+	n := runtime.Callers(3+skip, pcs[:]) //nolint:mnd
 
 	var st Stack = pcs[0:n]
 
@@ -207,7 +256,10 @@ func (e *Error) GetOriginalPredefinedError() error {
 			Is(err, ErrConflict),
 			Is(err, ErrPreconditionFailed),
 			Is(err, ErrValidation),
-			Is(err, ErrInternalServerError):
+			Is(err, ErrTooManyRequests),
+			Is(err, ErrCanceled),
+			Is(err, ErrInternalServerError),
+			Is(err, ErrTimeout):
 			predefinedErr = err
 		default:
 			return predefinedErr
@@ -18,6 +18,39 @@ var (
 	Errorf = fmt.Errorf //nolint:gochecknoglobals
 )
 
+// AsType finds the first error in the chain that matches the type T and returns it.
+// It is a generic alternative to As that does not require declaring a target variable.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - T: the first error in the chain of type T, or the zero value if none is found
+//   - bool: true if a matching error was found
+func AsType[T error](err error) (T, bool) {
+	var target T
+	if err == nil {
+		return target, false
+	}
+
+	ok := As(err, &target)
+
+	return target, ok
+}
+
+// Has reports whether any error in the chain matches the type T.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - bool: true if the chain contains an error of type T
+func Has[T error](err error) bool {
+	_, ok := AsType[T](err)
+
+	return ok
+}
+
 // FindOriginalErrorWithStack traverses an error chain to locate the latest framework error containing a call stack.
 //
 // Parameters:
@@ -26,23 +59,15 @@ var (
 // Returns:
 //   - *Error: the last framework error containing a call stack, or nil if none are found
 func FindOriginalErrorWithStack(err error) *Error {
-	var lastFrameworkErrWithStack *Error
+	found := Last(err, func(e error) bool {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
 
-	current := err
+		return ok && frameworkErr.GetCallStack() != nil
+	})
 
-	// Traverse the entire error chain.
-	for current != nil {
-		var frameworkErr *Error
-		if As(current, &frameworkErr) && frameworkErr.GetCallStack() != nil {
-			// Found a framework error with stack, save it.
-			lastFrameworkErrWithStack = frameworkErr
-		}
+	frameworkErr, _ := found.(*Error) //nolint:errorlint
 
-		// Continue unwrapping.
-		current = Unwrap(current)
-	}
-
-	return lastFrameworkErrWithStack
+	return frameworkErr
 }
 
 // FindFirstErrorWithStack traverses an error chain to locate the first framework-specific error.
@@ -53,20 +78,13 @@ func FindOriginalErrorWithStack(err error) *Error {
 // Returns:
 //   - *Error: the first framework-specific error in the chain, or nil if not found
 func FindFirstErrorWithStack(err error) error {
-	current := err
-
-	// Traverse the entire error chain.
-	for current != nil {
-		var frameworkErr *Error
-		if As(current, &frameworkErr) {
-			return frameworkErr
-		}
+	return First(err, isFrameworkError)
+}
 
-		// Continue unwrapping.
-		current = Unwrap(current)
-	}
+func isFrameworkError(err error) bool {
+	_, ok := err.(*Error) //nolint:errorlint
 
-	return current
+	return ok
 }
 
 // New creates a new Error instance with the specified description.
@@ -77,9 +95,9 @@ func FindFirstErrorWithStack(err error) error {
 // Returns:
 //   - error: an Error instance encapsulating the provided description.
 func New(description string) error {
-	return &Error{
-		Description: description,
-	}
+	return track(&Error{
+		Description: limitDescription(description),
+	})
 }
 
 // NewWithStack creates a new error with a description and captures the current call stack.
@@ -90,10 +108,10 @@ func New(description string) error {
 // Returns:
 //   - error: a newly created error with stack trace included
 func NewWithStack(description string) error {
-	return &Error{
-		Description: description,
+	return track(&Error{
+		Description: limitDescription(description),
 		stack:       callers(),
-	}
+	})
 }
 
 // Wrap wraps an existing error with additional context and a stack trace.
@@ -106,27 +124,120 @@ func NewWithStack(description string) error {
 //   - error: a wrapped error with the original error, description, and stack trace, or nil if the input error is nil
 func Wrap(err error, description string) error {
 	if err == nil {
+		recordNilWrap("Wrap", description, 0)
+
 		return nil
 	}
 
-	return &Error{
-		Description: description,
-		stack:       callers(),
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, 0),
 		error:       err,
+	})
+}
+
+// NewSkipping creates a new error with a description and a call stack, skipping the given number of
+// additional frames. Library authors building helpers on top of this package use it so the
+// captured stack starts at their caller instead of inside the helper.
+//
+// Parameters:
+//   - skip: the number of frames to skip above the caller of NewSkipping (0 behaves like NewWithStack)
+//   - description: the error description
+//
+// Returns:
+//   - error: a newly created error with stack trace included
+func NewSkipping(skip int, description string) error {
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       callersSkipping(skip),
+	})
+}
+
+// WrapSkipping wraps an existing error with a description and a call stack, skipping the given number of
+// additional frames, so helpers built on top of this package are excluded from the captured stack.
+//
+// Parameters:
+//   - err: the original error to wrap
+//   - skip: the number of frames to skip above the caller of WrapSkipping (0 behaves like Wrap)
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - error: a wrapped error with stack trace, or nil if the input error is nil
+func WrapSkipping(err error, skip int, description string) error {
+	if err == nil {
+		recordNilWrap("WrapSkipping", description, skip)
+
+		return nil
 	}
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, skip),
+		error:       err,
+	})
 }
 
 // Wrapf logs the given error with a formatted message and wraps the error with the same message.
 func Wrapf(err error, format string, args ...any) error {
 	if err == nil {
+		recordNilWrap("Wrapf", format, 0)
+
 		return nil
 	}
 
-	return &Error{
-		Description: fmt.Sprintf(format, args...),
-		stack:       callers(),
+	description, fields := formatDescription(format, args)
+
+	return track(&Error{
+		Description: description,
+		stack:       stackFor(err, 0),
 		error:       err,
+		fields:      fields,
+	})
+}
+
+// NewE creates a new Error with a description and a call stack, returning the concrete type
+// so option methods can be chained fluently:
+//
+//	return errors.NewE("quota exceeded").WithCode(CodeQuota).WithField("limit", limit)
+//
+// Parameters:
+//   - description: the error description
+//
+// Returns:
+//   - *Error: the newly created error
+func NewE(description string) *Error {
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       callers(),
+	})
+}
+
+// WrapE wraps an existing error with a description and a call stack, returning the concrete type
+// so option methods can be chained fluently:
+//
+//	return errors.WrapE(err, "charging card").WithCode(CodePayment).WithField("order_id", id)
+//
+// Beware that a nil *Error stored in an error interface is not a nil error: only return the result
+// as an error once err is known to be non-nil.
+//
+// Parameters:
+//   - err: the original error to wrap
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - *Error: the wrapped error, or nil if the input error is nil
+func WrapE(err error, description string) *Error {
+	if err == nil {
+		recordNilWrap("WrapE", description, 0)
+
+		return nil
 	}
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, 0),
+		error:       err,
+	})
 }
 
 // WrapfWithCustomErr creates a new Error instance by wrapping an original error with a custom error and formatted message.
@@ -141,14 +252,24 @@ func Wrapf(err error, format string, args ...any) error {
 //   - error: an Error with formatted description and wrapped errors, or nil if the original error is nil
 func WrapfWithCustomErr(originalErr, wrappingErr error, format string, args ...any) error {
 	if originalErr == nil {
+		recordNilWrap("WrapfWithCustomErr", format, 0)
+
 		return nil
 	}
 
-	return &Error{
-		Description: fmt.Sprintf(format, args...),
-		stack:       callers(),
-		error:       fmt.Errorf("%w: %v", wrappingErr, originalErr),
+	if wrappingErr == nil {
+		reportMisuse("WrapfWithCustomErr called with a nil wrapping error")
 	}
+
+	description, fields := formatDescription(format, args)
+	cause := fmt.Errorf("%w: %v", wrappingErr, originalErr)
+
+	return track(&Error{
+		Description: description,
+		stack:       stackFor(cause, 0),
+		error:       cause,
+		fields:      fields,
+	})
 }
 
 // WrapWithCustomErr wraps an original error with a custom error, maintaining context and a call stack.
@@ -161,13 +282,21 @@ func WrapfWithCustomErr(originalErr, wrappingErr error, format string, args ...a
 //   - error: a new error combining the original and custom errors, or nil if the original error is nil
 func WrapWithCustomErr(originalErr, wrappingErr error) error {
 	if originalErr == nil {
+		recordNilWrap("WrapWithCustomErr", "", 0)
+
 		return nil
 	}
 
-	return &Error{
-		stack: callers(),
-		error: fmt.Errorf("%w: %v", wrappingErr, originalErr),
+	if wrappingErr == nil {
+		reportMisuse("WrapWithCustomErr called with a nil wrapping error")
 	}
+
+	cause := fmt.Errorf("%w: %v", wrappingErr, originalErr)
+
+	return track(&Error{
+		stack: stackFor(cause, 0),
+		error: cause,
+	})
 }
 
 // AddCustomCallStack wraps the given error with a custom call stack and returns a new error that includes both.
@@ -185,9 +314,9 @@ func AddCustomCallStack(err error, callStack *Stack) error {
 		return nil
 	}
 
-	return &Error{
+	return track(&Error{
 		Description: err.Error(),
 		stack:       callStack,
 		error:       err,
-	}
+	})
 }
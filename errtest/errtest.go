@@ -0,0 +1,148 @@
+// Package errtest provides test assertions on errors built with github.com/ceearrashee/errors, matching the message
+// of each chain level with regular expressions so tests assert structure rather than brittle full-string equality:
+//
+//	errtest.AssertChain(t, err, []string{`^load user \d+$`, `^query users$`, `not found`})
+package errtest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+// Segments returns the message contributed by each level of the chain of err: the description of every
+// *errors.Error level that has one, followed by the full message of the first error of another type,
+// which cannot be split further.
+//
+// Parameters:
+//   - err: the error to split
+//
+// Returns:
+//   - []string: the messages from the outermost level inwards, or nil if err is nil
+func Segments(err error) []string {
+	var segments []string
+
+	for e := range errors.Chain(err) {
+		frameworkErr, ok := e.(*errors.Error) //nolint:errorlint
+		if !ok {
+			segments = append(segments, e.Error())
+
+			break
+		}
+
+		if frameworkErr.Description != "" {
+			segments = append(segments, frameworkErr.Description)
+		}
+	}
+
+	return segments
+}
+
+// AssertMessageMatches checks that the message of err matches the regular expression, failing the test otherwise.
+//
+// Parameters:
+//   - t: the test to fail
+//   - err: the error to check
+//   - pattern: the regular expression the message must match
+//
+// Returns:
+//   - bool: true if the message matches
+func AssertMessageMatches(t testing.TB, err error, pattern string) bool {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected an error matching %q, got nil", pattern)
+
+		return false
+	}
+
+	re, ok := compile(t, pattern)
+	if !ok {
+		return false
+	}
+
+	if !re.MatchString(err.Error()) {
+		t.Errorf("error message %q does not match %q", err.Error(), pattern)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertChain checks that err has one chain level per regular expression, each matching the message
+// of its level (see Segments), failing the test otherwise.
+//
+// Parameters:
+//   - t: the test to fail
+//   - err: the error to check
+//   - patterns: the regular expressions of each level, from the outermost level inwards
+//
+// Returns:
+//   - bool: true if every level matches
+func AssertChain(t testing.TB, err error, patterns []string) bool {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("expected an error with %d chain levels, got nil", len(patterns))
+
+		return false
+	}
+
+	segments := Segments(err)
+	if len(segments) != len(patterns) {
+		t.Errorf("error has %d chain levels, want %d:\n%s", len(segments), len(patterns), describe(segments))
+
+		return false
+	}
+
+	matched := true
+
+	for i, pattern := range patterns {
+		re, ok := compile(t, pattern)
+		if !ok {
+			matched = false
+
+			continue
+		}
+
+		if !re.MatchString(segments[i]) {
+			t.Errorf("chain level %d %q does not match %q:\n%s", i, segments[i], pattern, describe(segments))
+
+			matched = false
+		}
+	}
+
+	return matched
+}
+
+func compile(t testing.TB, pattern string) (*regexp.Regexp, bool) {
+	t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		t.Errorf("invalid pattern %q: %v", pattern, err)
+
+		return nil, false
+	}
+
+	return re, true
+}
+
+// describe lists the chain levels for failure messages.
+func describe(segments []string) string {
+	var b strings.Builder
+
+	for i, segment := range segments {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		_, _ = fmt.Fprintf(&b, "  [%d] %q", i, segment) //nolint:errcheck
+	}
+
+	return b.String()
+}
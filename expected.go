@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+var (
+	expectedErrors atomic.Pointer[[]error] //nolint:gochecknoglobals
+	expectedStacks atomic.Bool             //nolint:gochecknoglobals
+)
+
+// SetExpectedStackMode controls whether wrapping an expected error (a definition marked Expected, such as
+// ErrNotFound and ErrValidation) captures a call stack. It defaults to StackOmit, since these errors are
+// control flow in most applications; pass StackCapture to record stacks for them again.
+//
+// Parameters:
+//   - mode: the stack mode applied to wrappers of expected errors
+func SetExpectedStackMode(mode StackMode) {
+	expectedStacks.Store(mode == StackCapture)
+}
+
+// IsExpected reports whether the error chain matches a registered definition marked Expected.
+//
+// Parameters:
+//   - err: the error to check
+//
+// Returns:
+//   - bool: true if the error is expected
+func IsExpected(err error) bool {
+	sentinels := expectedErrors.Load()
+	if err == nil || sentinels == nil {
+		return false
+	}
+
+	for _, sentinel := range *sentinels {
+		if Is(err, sentinel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stackFor captures the call stack of a wrapper of err like callersSkipping, unless err is expected
+// and expected errors omit their stack.
+func stackFor(err error, skip int) *Stack {
+	if !expectedStacks.Load() && IsExpected(err) {
+		return nil
+	}
+
+	return callersSkipping(skip + 1)
+}
+
+// refreshExpected rebuilds the expected sentinels; the caller holds registryMu.
+func refreshExpected(defs []ErrorDefinition) {
+	sentinels := make([]error, 0, len(defs))
+
+	for _, def := range defs {
+		if def.Expected && def.Err != nil {
+			sentinels = append(sentinels, def.Err)
+		}
+	}
+
+	expectedErrors.Store(&sentinels)
+}
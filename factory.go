@@ -0,0 +1,275 @@
+package errors
+
+import "slices"
+
+const (
+	// FieldPackage is the field holding the name of the package whose factory created the error.
+	FieldPackage = "package"
+	// FieldDomain is the field holding the business domain set by WithDomain.
+	FieldDomain = "domain"
+	// FieldOwner is the field holding the owning team set by WithOwner.
+	FieldOwner = "owner"
+	// FieldTenant is the field holding the tenant set by WithTenant.
+	FieldTenant = "tenant"
+)
+
+const (
+	// StackCapture records the call stack of created errors (the default).
+	StackCapture StackMode = iota
+	// StackOmit drops the call stack of created errors, for errors used as expected control flow.
+	StackOmit
+)
+
+type (
+	// StackMode controls whether errors carry a call stack.
+	StackMode int
+
+	// Factory creates errors carrying the same defaults, so a package declares its error policy once:
+	//
+	//	var errs = errors.ForPackage("billing", errors.WithOwner("payments"), errors.WithCodePrefix("BILL-"))
+	//
+	//	return errs.Wrap(err, "charging card")
+	Factory struct {
+		opts []Option
+	}
+)
+
+// ForPackage returns a factory whose errors record the package name and carry the given defaults,
+// such as the domain, owner, stack mode and code prefix.
+//
+// Parameters:
+//   - name: the name of the package, recorded in the FieldPackage field
+//   - opts: the options applied to every error created by the factory
+//
+// Returns:
+//   - *Factory: the package factory
+func ForPackage(name string, opts ...Option) *Factory {
+	return NewFactory(append([]Option{WithField(FieldPackage, name)}, opts...)...)
+}
+
+// NewFactory returns a factory scoped to a component instance, whose errors carry its identity:
+//
+//	errs := errors.NewFactory(errors.WithFields(errors.Fields{"component": "worker-7"}))
+//
+// Parameters:
+//   - opts: the options applied to every error created by the factory
+//
+// Returns:
+//   - *Factory: the factory
+func NewFactory(opts ...Option) *Factory {
+	return &Factory{opts: slices.Clone(opts)}
+}
+
+// With returns a factory applying the options after the defaults of f, e.g. to scope a package factory
+// to a shard or tenant. The receiver is left untouched.
+//
+// Parameters:
+//   - opts: the additional options
+//
+// Returns:
+//   - *Factory: the derived factory
+func (f *Factory) With(opts ...Option) *Factory {
+	return &Factory{opts: slices.Concat(f.opts, opts)}
+}
+
+// WithDomain records the business domain of the error, e.g. "billing".
+//
+// Parameters:
+//   - domain: the domain of the error
+//
+// Returns:
+//   - Option: the option recording the domain in the FieldDomain field
+func WithDomain(domain string) Option {
+	return WithField(FieldDomain, domain)
+}
+
+// WithOwner sets the team owning the error, taking precedence over the owners resolved by OwnerOf from the stack.
+//
+// Parameters:
+//   - team: the owning team
+//
+// Returns:
+//   - Option: the option recording the owner in the FieldOwner field
+func WithOwner(team string) Option {
+	return WithField(FieldOwner, team)
+}
+
+// WithTenant records the tenant the error occurred for in multi-tenant services, so reporters apply its quota
+// (see AllowTenantReport) and tag its reports. Register a context extractor returning the FieldTenant field to
+// record it on every WrapCtxf call.
+//
+// Parameters:
+//   - id: the tenant identifier
+//
+// Returns:
+//   - Option: the option recording the tenant in the FieldTenant field
+func WithTenant(id string) Option {
+	return WithField(FieldTenant, id)
+}
+
+// WithCodePrefix sets the prefix of the reference code derived by AutoCode, so codes tell which package created them.
+//
+// Parameters:
+//   - prefix: the prefix, e.g. "BILL-"
+//
+// Returns:
+//   - Option: the option setting the prefix
+func WithCodePrefix(prefix string) Option {
+	return func(e *Error) {
+		e.codePrefix = prefix
+	}
+}
+
+// WithStackMode controls whether the error keeps its call stack; StackOmit drops it.
+//
+// Parameters:
+//   - mode: the stack mode
+//
+// Returns:
+//   - Option: the option applying the stack mode
+func WithStackMode(mode StackMode) Option {
+	return func(e *Error) {
+		if mode == StackOmit {
+			e.stack = nil
+		}
+	}
+}
+
+// WithCallStack replaces the call stack of the error, e.g. with one recorded by a StackBuffer or a synthetic one,
+// including for expected errors created without a stack.
+//
+// Parameters:
+//   - stack: the call stack to attach; nil drops the stack
+//
+// Returns:
+//   - Option: the option attaching the stack
+func WithCallStack(stack *Stack) Option {
+	return func(e *Error) {
+		e.stack = stack
+	}
+}
+
+// DomainOf returns the domain recorded with WithDomain by the outermost error of the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - string: the domain, or an empty string if none was recorded
+func DomainOf(err error) string {
+	domain, _ := FieldsOf(err)[FieldDomain].(string)
+
+	return domain
+}
+
+// New creates an error with a description and a call stack, carrying the factory defaults.
+//
+// Parameters:
+//   - description: the error description
+//
+// Returns:
+//   - error: the newly created error
+func (f *Factory) New(description string) error {
+	return f.apply(track(&Error{
+		Description: limitDescription(description),
+		stack:       callers(),
+	}))
+}
+
+// Newf creates an error with a formatted description and a call stack, carrying the factory defaults.
+//
+// Parameters:
+//   - format: the format of the description
+//   - args: the values interpolated into the format
+//
+// Returns:
+//   - error: the newly created error
+func (f *Factory) Newf(format string, args ...any) error {
+	description, fields := formatDescription(format, args)
+
+	return f.apply(track(&Error{
+		Description: description,
+		stack:       callers(),
+		fields:      fields,
+	}))
+}
+
+// Wrap wraps an error with a description and a call stack, carrying the factory defaults.
+//
+// Parameters:
+//   - err: the error to wrap
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil
+func (f *Factory) Wrap(err error, description string) error {
+	if err == nil {
+		recordNilWrap("Factory.Wrap", description, 0)
+
+		return nil
+	}
+
+	return f.apply(track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, 0),
+		error:       err,
+	}))
+}
+
+// Wrapf wraps an error with a formatted description and a call stack, carrying the factory defaults.
+//
+// Parameters:
+//   - err: the error to wrap
+//   - format: the format of the description
+//   - args: the values interpolated into the format
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil
+func (f *Factory) Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		recordNilWrap("Factory.Wrapf", format, 0)
+
+		return nil
+	}
+
+	description, fields := formatDescription(format, args)
+
+	return f.apply(track(&Error{
+		Description: description,
+		stack:       stackFor(err, 0),
+		error:       err,
+		fields:      fields,
+	}))
+}
+
+// Annotate applies the factory defaults followed by opts to err, like the package-level Annotate.
+//
+// Parameters:
+//   - err: the error to annotate; if nil, nil is returned
+//   - opts: additional options applied after the defaults
+//
+// Returns:
+//   - error: the annotated error
+func (f *Factory) Annotate(err error, opts ...Option) error {
+	return Annotate(err, slices.Concat(f.opts, opts)...)
+}
+
+// apply applies the factory defaults to a freshly created error.
+func (f *Factory) apply(e *Error) error {
+	for _, opt := range f.opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// codePrefixOf returns the outermost code prefix of the chain.
+func codePrefixOf(err error) string {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.codePrefix != "" { //nolint:errorlint
+			return frameworkErr.codePrefix
+		}
+	}
+
+	return ""
+}
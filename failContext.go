@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"context"
+)
+
+// WithFailure returns a context that goroutines sharing it can cancel with an error as the cause, through FailContext,
+// so a failing worker stops its siblings and they can tell why with CauseOf.
+//
+// Parameters:
+//   - parent: the parent context to derive from
+//
+// Returns:
+//   - context.Context: the derived context
+//   - context.CancelFunc: cancels the context with context.Canceled as the cause, releasing its resources
+func WithFailure(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	ctx = context.WithValue(ctx, failContextKey, cancel)
+
+	return ctx, func() { cancel(nil) }
+}
+
+// FailContext cancels the nearest context derived with WithFailure, recording err as its cause together with
+// the stack of the cancellation site. Only the first failure is recorded, like context.CancelCauseFunc.
+//
+// Parameters:
+//   - ctx: a context derived from WithFailure
+//   - err: the error causing the cancellation; nil is ignored
+//
+// Returns:
+//   - bool: true if the context was canceled, or already done; false if err is nil or ctx was not derived from WithFailure
+func FailContext(ctx context.Context, err error) bool {
+	cancel, ok := ctx.Value(failContextKey).(context.CancelCauseFunc)
+	if !ok || err == nil {
+		return false
+	}
+
+	cancel(track(&Error{stack: callers(), error: err}))
+
+	return true
+}
+
+// CauseOf returns why the context was canceled: the error passed to FailContext, carrying the stack
+// of the cancellation site, or the cause recorded by the standard library otherwise (see context.Cause).
+//
+// Parameters:
+//   - ctx: the context to inspect
+//
+// Returns:
+//   - error: the cause of the cancellation, or nil if the context is not done
+func CauseOf(ctx context.Context) error {
+	return context.Cause(ctx)
+}
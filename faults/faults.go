@@ -0,0 +1,226 @@
+// Package faults injects classified errors at named points of the code for chaos experiments, exercising the whole
+// error pipeline — wrapping, classification, HTTP and gRPC mapping, logging and reporting — with realistic failures:
+//
+//	func (s *Service) Charge(ctx context.Context, order Order) error {
+//		if err := faults.Maybe(ctx, "billing.charge"); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Injection is disabled until Configure is called with rules, e.g. loaded at runtime from a JSON document with
+// ParseConfig; Maybe is a single atomic load when no rule is configured.
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FieldPoint is the field holding the injection point an error was injected at.
+	FieldPoint = "fault.point"
+	// TagInjected is the tag of injected errors, letting reporters and dashboards tell them from real failures.
+	TagInjected = "fault_injected"
+	// Wildcard is the point suffix matching every point under a prefix, e.g. "billing.*".
+	Wildcard = "*"
+)
+
+type (
+	// Rule describes the faults injected at the points it matches.
+	Rule struct {
+		// Point is the injection point the rule applies to: an exact name, a prefix followed by Wildcard,
+		// or Wildcard alone for every point.
+		Point string `json:"point"`
+		// Code is the registered code of the injected error; the code of errors.ErrInternalServerError if empty.
+		Code errors.ErrorCode `json:"code,omitempty"`
+		// Percent is the probability, between 0 and 100, of injecting the error on each call.
+		Percent float64 `json:"percent,omitempty"`
+		// RequestIDs are requests the error is always injected into, whatever Percent, see RequestID.
+		RequestIDs []string `json:"request_ids,omitempty"`
+	}
+
+	// Config is the set of rules applied by Maybe; the first rule matching a call wins.
+	Config struct {
+		Rules []Rule `json:"rules"`
+	}
+
+	// RequestIDFunc returns the ID of the request carried by the context, or an empty string if there is none.
+	RequestIDFunc func(ctx context.Context) string
+
+	requestIDKey struct{}
+)
+
+var (
+	config        atomic.Pointer[Config]        //nolint:gochecknoglobals
+	requestIDFunc atomic.Pointer[RequestIDFunc] //nolint:gochecknoglobals
+)
+
+// Configure replaces the rules applied by Maybe. It is safe to call at any time, e.g. when a runtime
+// configuration source changes.
+//
+// Parameters:
+//   - cfg: the rules to apply; a zero Config disables injection
+func Configure(cfg Config) {
+	if len(cfg.Rules) == 0 {
+		config.Store(nil)
+
+		return
+	}
+
+	cfg.Rules = slices.Clone(cfg.Rules)
+	config.Store(&cfg)
+}
+
+// ParseConfig decodes a JSON configuration, such as
+// {"rules": [{"point": "billing.*", "code": "timeout", "percent": 5}]}.
+//
+// Parameters:
+//   - data: the JSON document
+//
+// Returns:
+//   - Config: the decoded configuration
+//   - error: an error if data is malformed or refers to an unregistered code
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, errors.Wrap(err, "parse fault configuration")
+	}
+
+	for _, rule := range cfg.Rules {
+		if _, ok := errors.DefinitionByCode(rule.Code); rule.Code != "" && !ok {
+			return Config{}, errors.Annotate(errors.New("unregistered fault code"),
+				errors.WithField(FieldPoint, rule.Point), errors.WithField("fault.code", rule.Code))
+		}
+	}
+
+	return cfg, nil
+}
+
+// SetRequestIDFunc sets how Maybe reads the request ID matched against Rule.RequestIDs, e.g. from the
+// request ID middleware of the application. By default, the ID set by WithRequestID is used.
+//
+// Parameters:
+//   - fn: the function reading the request ID; nil restores the default
+func SetRequestIDFunc(fn RequestIDFunc) {
+	if fn == nil {
+		requestIDFunc.Store(nil)
+
+		return
+	}
+
+	requestIDFunc.Store(&fn)
+}
+
+// WithRequestID derives a context carrying the ID of the request, matched against Rule.RequestIDs.
+//
+// Parameters:
+//   - parent: the parent context
+//   - id: the request ID
+//
+// Returns:
+//   - context.Context: the derived context
+func WithRequestID(parent context.Context, id string) context.Context {
+	return context.WithValue(parent, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the request carried by ctx, as matched against Rule.RequestIDs.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - string: the request ID, or an empty string if there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if fn := requestIDFunc.Load(); fn != nil {
+		return (*fn)(ctx)
+	}
+
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// Maybe returns an injected error when a configured rule matches the point and the request, and nil otherwise.
+// The error is classified with the registered definition of the rule code, tagged TagInjected, records the point in
+// FieldPoint, and carries a synthetic stack starting at the caller of Maybe, even for expected errors, so the
+// failure flows through stack-dependent reporting like a real one.
+//
+// Parameters:
+//   - ctx: the context of the request, used to match Rule.RequestIDs
+//   - point: the name of the injection point, e.g. "billing.charge"
+//
+// Returns:
+//   - error: the injected error, or nil
+func Maybe(ctx context.Context, point string) error {
+	cfg := config.Load()
+	if cfg == nil {
+		return nil
+	}
+
+	rule, ok := cfg.match(ctx, point)
+	if !ok {
+		return nil
+	}
+
+	sentinel := errors.ErrInternalServerError
+	if def, found := errors.DefinitionByCode(rule.Code); found && def.Err != nil {
+		sentinel = def.Err
+	}
+
+	var buf errors.StackBuffer
+
+	buf.Capture(1)
+
+	return errors.Annotate(errors.Wrap(sentinel, "injected fault"),
+		errors.WithCallStack(buf.Stack()), errors.WithField(FieldPoint, point), errors.WithTags(TagInjected))
+}
+
+// match returns the first rule matching the point and firing for the request.
+func (c *Config) match(ctx context.Context, point string) (Rule, bool) {
+	var (
+		requestID string
+		resolved  bool
+	)
+
+	for _, rule := range c.Rules {
+		if !matchPoint(rule.Point, point) {
+			continue
+		}
+
+		if len(rule.RequestIDs) > 0 {
+			if !resolved {
+				requestID, resolved = RequestID(ctx), true
+			}
+
+			if requestID != "" && slices.Contains(rule.RequestIDs, requestID) {
+				return rule, true
+			}
+		}
+
+		if rule.Percent > 0 && rand.Float64()*100 < rule.Percent { //nolint:gosec,mnd
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// matchPoint reports whether the point pattern of a rule matches point.
+func matchPoint(pattern, point string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, Wildcard); ok {
+		return strings.HasPrefix(point, prefix)
+	}
+
+	return pattern == point
+}
@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"maps"
+	"sync/atomic"
+)
+
+const (
+	// FieldMergeLazy collects fields by walking the chain each time FieldsOf is called (the default).
+	FieldMergeLazy FieldMergeMode = iota
+	// FieldMergeEager copies the fields of the wrapped chain into every new wrapper, child values taking precedence,
+	// so FieldsOf reads the outermost error only. Wrapping costs a copy of the fields, reading becomes constant time.
+	FieldMergeEager
+)
+
+type (
+	// Fields holds structured key-value data attached to an error.
+	Fields map[string]any
+
+	// FieldMergeMode selects when the fields of a chain are merged.
+	FieldMergeMode int32
+)
+
+var fieldMergeMode atomic.Int32 //nolint:gochecknoglobals
+
+// SetFieldMergeMode sets when the fields of a chain are merged: lazily at read time by FieldsOf, or eagerly
+// by each wrapper at creation time. Merged fields are copied, so wrapped errors are never mutated.
+// Errors created before switching keep the mode they were created with.
+//
+// Parameters:
+//   - mode: the merge mode to apply
+func SetFieldMergeMode(mode FieldMergeMode) {
+	fieldMergeMode.Store(int32(mode))
+}
+
+// FieldsOf collects the structured fields attached anywhere in the chain.
+// When the same key is set at several levels, the outermost value wins.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - Fields: the merged fields, or nil if there are none
+func FieldsOf(err error) Fields {
+	if frameworkErr, ok := err.(*Error); ok && frameworkErr.fieldsMerged { //nolint:errorlint
+		return maps.Clone(frameworkErr.fields)
+	}
+
+	var fields Fields
+
+	for e := range Chain(err) {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
+		if !ok {
+			continue
+		}
+
+		for key, value := range frameworkErr.fields {
+			if fields == nil {
+				fields = make(Fields)
+			}
+
+			if _, exists := fields[key]; !exists {
+				fields[key] = value
+			}
+		}
+	}
+
+	return fields
+}
+
+// inheritFields copies the fields of the chain wrapped by e into e under its own, when eager merging is enabled.
+func inheritFields(e *Error) {
+	if FieldMergeMode(fieldMergeMode.Load()) != FieldMergeEager {
+		return
+	}
+
+	inherited := FieldsOf(e.error)
+	if len(inherited) > 0 {
+		maps.Copy(inherited, e.fields)
+		e.fields = inherited
+	}
+
+	e.fieldsMerged = true
+}
@@ -0,0 +1,73 @@
+package errors_test
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+// benchmarkChainDepth is the number of wrappers, each carrying a field, of the chains built by the benchmarks.
+const benchmarkChainDepth = 8
+
+func BenchmarkFieldsOfEager(b *testing.B) {
+	benchmarkFieldsOf(b, errors.FieldMergeEager)
+}
+
+func BenchmarkFieldsOfLazy(b *testing.B) {
+	benchmarkFieldsOf(b, errors.FieldMergeLazy)
+}
+
+func BenchmarkWrapEager(b *testing.B) {
+	benchmarkWrap(b, errors.FieldMergeEager)
+}
+
+func BenchmarkWrapLazy(b *testing.B) {
+	benchmarkWrap(b, errors.FieldMergeLazy)
+}
+
+func benchmarkFieldsOf(b *testing.B, mode errors.FieldMergeMode) {
+	b.Helper()
+	setFieldMergeMode(b, mode)
+
+	err := fieldChain(benchmarkChainDepth)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if len(errors.FieldsOf(err)) != benchmarkChainDepth {
+			b.Fatal("FieldsOf lost fields of the chain")
+		}
+	}
+}
+
+func benchmarkWrap(b *testing.B, mode errors.FieldMergeMode) {
+	b.Helper()
+	setFieldMergeMode(b, mode)
+
+	err := fieldChain(benchmarkChainDepth - 1)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = errors.Annotate(errors.Wrap(err, "outer"), errors.WithField("outer", true))
+	}
+}
+
+// fieldChain builds a chain of depth wrappers, each holding a field of its own.
+func fieldChain(depth int) error {
+	err := io.EOF
+
+	for i := range depth {
+		err = errors.Annotate(errors.Wrap(err, "level "+strconv.Itoa(i)), errors.WithField("level."+strconv.Itoa(i), i))
+	}
+
+	return err
+}
+
+func setFieldMergeMode(b *testing.B, mode errors.FieldMergeMode) {
+	b.Helper()
+	errors.SetFieldMergeMode(mode)
+	b.Cleanup(func() { errors.SetFieldMergeMode(errors.FieldMergeLazy) })
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Filter rebuilds err without the members of joined errors rejected by keep, such as context.Canceled
+// noise from fan-out cancellations. Aggregates and errors joined with Join are filtered member by member,
+// including below any number of wrappers (*Error, fmt.Errorf with %w...) or nested in other joined errors;
+// retained members are kept as-is, with their stacks, and joined errors none of whose members changed are
+// returned unchanged, with their wrappers.
+//
+// Parameters:
+//   - err: the error to filter
+//   - keep: reports whether a non-joined error should be retained
+//
+// Returns:
+//   - error: the filtered error, or nil if no member is retained
+func Filter(err error, keep func(error) bool) error {
+	switch x := err.(type) { //nolint:errorlint
+	case nil:
+		return nil
+	case *Aggregate:
+		kept, _ := filterMembers(x.errs, keep)
+
+		return Append(nil, kept...)
+	case *Error:
+		if !wrapsJoined(x.error) {
+			break
+		}
+
+		inner := Filter(x.error, keep)
+		if inner == nil {
+			return nil
+		}
+
+		if unchanged(x.error, inner) {
+			return err
+		}
+
+		filtered := x.clone()
+		filtered.error = inner
+
+		return filtered
+	case interface{ Unwrap() []error }:
+		kept, changed := filterMembers(x.Unwrap(), keep)
+		if !changed {
+			return err
+		}
+
+		return Join(kept...)
+	case interface{ Unwrap() error }:
+		wrapped := x.Unwrap()
+		if !wrapsJoined(wrapped) {
+			break
+		}
+
+		inner := Filter(wrapped, keep)
+		if inner == nil {
+			return nil
+		}
+
+		if unchanged(wrapped, inner) {
+			return err
+		}
+
+		if prefix, ok := strings.CutSuffix(err.Error(), wrapped.Error()); ok {
+			return fmt.Errorf("%s%w", prefix, inner)
+		}
+
+		return &rebuiltWrapper{msg: err.Error(), errs: []error{inner}}
+	}
+
+	if !keep(err) {
+		return nil
+	}
+
+	return err
+}
+
+// IgnoreFn returns nil if ignore reports true for err, and err otherwise.
+//
+// Parameters:
+//   - err: the error to filter
+//   - ignore: reports whether the error should be ignored
+//
+// Returns:
+//   - error: nil if err is nil or ignored, err unchanged otherwise
+func IgnoreFn(err error, ignore func(error) bool) error {
+	if err == nil || ignore(err) {
+		return nil
+	}
+
+	return err
+}
+
+// filterMembers filters each member recursively, reporting whether any member was dropped or rebuilt.
+func filterMembers(members []error, keep func(error) bool) ([]error, bool) {
+	kept := make([]error, 0, len(members))
+	changed := false
+
+	for _, member := range members {
+		filtered := Filter(member, keep)
+		if filtered != nil {
+			kept = append(kept, filtered)
+		}
+
+		if filtered == nil || !unchanged(member, filtered) {
+			changed = true
+		}
+	}
+
+	return kept, changed
+}
+
+// unchanged reports whether Filter returned err itself. Comparisons panic on errors of the same non-comparable
+// dynamic type, which are reported changed.
+func unchanged(err, filtered error) bool {
+	return reflect.TypeOf(err).Comparable() && filtered == err //nolint:errorlint
+}
+
+// wrapsJoined reports whether a joined error is reached by unwrapping err, one Unwrap() error level at a time.
+func wrapsJoined(err error) bool {
+	for err != nil {
+		if _, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+			return true
+		}
+
+		wrapper, ok := err.(interface{ Unwrap() error }) //nolint:errorlint
+		if !ok {
+			return false
+		}
+
+		err = wrapper.Unwrap()
+	}
+
+	return false
+}
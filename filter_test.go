@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestFilterReachesJoinedErrorsThroughWrappers(t *testing.T) {
+	t.Parallel()
+
+	notCanceled := func(err error) bool { return !errors.Is(err, context.Canceled) }
+
+	cases := map[string]error{
+		"joined":             errors.Join(io.EOF, context.Canceled),
+		"wrapped":            errors.Wrap(errors.Join(io.EOF, context.Canceled), "fan-out"),
+		"wrapped twice":      errors.Wrap(errors.Wrap(errors.Join(io.EOF, context.Canceled), "fan-out"), "handler"),
+		"wrapped with fmt":   fmt.Errorf("handler: %w", errors.Wrap(errors.Join(io.EOF, context.Canceled), "fan-out")),
+		"wrapped around fmt": errors.Wrap(fmt.Errorf("fan-out: %w", errors.Join(io.EOF, context.Canceled)), "handler"),
+		"aggregate wrapped":  errors.Wrap(errors.Wrap(errors.Append(io.EOF, context.Canceled), "fan-out"), "handler"),
+	}
+
+	for name, err := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filtered := errors.Filter(err, notCanceled)
+
+			if !errors.Is(filtered, io.EOF) {
+				t.Fatalf("Filter(%q) = %q, want io.EOF kept", err.Error(), filtered)
+			}
+
+			if errors.Is(filtered, context.Canceled) {
+				t.Fatalf("Filter(%q) = %q, want context.Canceled dropped", err.Error(), filtered)
+			}
+		})
+	}
+}
+
+func TestFilterDropsWrappersOfRejectedErrors(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(errors.Wrap(errors.Join(context.Canceled, context.Canceled), "fan-out"), "handler")
+
+	if filtered := errors.Filter(err, func(err error) bool { return !errors.Is(err, context.Canceled) }); filtered != nil {
+		t.Fatalf("Filter = %q, want nil", filtered)
+	}
+}
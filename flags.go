@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// FieldFlagPrefix prefixes the fields holding the feature flags active when an error occurred, e.g. "flag.new_checkout".
+const FieldFlagPrefix = "flag."
+
+type (
+	// FlagProvider returns the feature flags active for the request carried by the context, keyed by flag name.
+	FlagProvider func(ctx context.Context) map[string]string
+)
+
+var (
+	flagProvidersMu sync.Mutex                     //nolint:gochecknoglobals
+	flagProviders   atomic.Pointer[[]FlagProvider] //nolint:gochecknoglobals
+)
+
+// RegisterFlagProvider adds a provider of the feature flags active at error time. Errors wrapped with WrapCtxf
+// record the flags into FieldFlagPrefix fields, and reporters attach them through ActiveFlags, enabling analysis
+// of errors occurring only with a flag on. Providers apply in registration order; later ones override earlier flags.
+//
+// Parameters:
+//   - provider: the provider to add; nil is ignored
+func RegisterFlagProvider(provider FlagProvider) {
+	if provider == nil {
+		return
+	}
+
+	flagProvidersMu.Lock()
+	defer flagProvidersMu.Unlock()
+
+	var registered []FlagProvider
+	if current := flagProviders.Load(); current != nil {
+		registered = slices.Clone(*current)
+	}
+
+	registered = append(registered, provider)
+	flagProviders.Store(&registered)
+}
+
+// ActiveFlags returns the feature flags reported by the registered providers for ctx.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - map[string]string: the active flags keyed by name, or nil if there are none
+func ActiveFlags(ctx context.Context) map[string]string {
+	providers := flagProviders.Load()
+	if ctx == nil || providers == nil {
+		return nil
+	}
+
+	var flags map[string]string
+
+	for _, provider := range *providers {
+		provided := provider(ctx)
+		if len(provided) == 0 {
+			continue
+		}
+
+		if flags == nil {
+			flags = make(map[string]string, len(provided))
+		}
+
+		maps.Copy(flags, provided)
+	}
+
+	return flags
+}
+
+// flagFields returns the active flags of ctx as FieldFlagPrefix fields.
+func flagFields(ctx context.Context) Fields {
+	flags := ActiveFlags(ctx)
+	if len(flags) == 0 {
+		return nil
+	}
+
+	fields := make(Fields, len(flags))
+	for name, value := range flags {
+		fields[FieldFlagPrefix+name] = value
+	}
+
+	return fields
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// OriginApp marks frames of the main module of the running binary or of a module registered with SetAppModules.
+	OriginApp FrameOrigin = iota
+	// OriginDependency marks frames of third-party modules.
+	OriginDependency
+	// OriginStdlib marks frames of the Go standard library and runtime.
+	OriginStdlib
+)
+
+type (
+	// FrameOrigin tells who owns the code of a stack frame, as a trust level for reading stacks.
+	FrameOrigin int
+
+	// Frame is a resolved frame of a call stack.
+	Frame struct {
+		Function string      `json:"function"`
+		File     string      `json:"file"`
+		Line     int         `json:"line"`
+		Origin   FrameOrigin `json:"origin"`
+		// Inlined reports that the compiler inlined the function into its caller; the frame is still
+		// reported on its own, so small wrappers never hide the actual caller.
+		Inlined bool `json:"inlined,omitempty"`
+	}
+)
+
+//nolint:gochecknoglobals
+var (
+	appModules atomic.Pointer[[]string]
+
+	mainModule = sync.OnceValue(func() string {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			return info.Main.Path
+		}
+
+		return ""
+	})
+)
+
+// SetAppModules declares additional module paths whose frames are application code, such as the other modules
+// of a monorepo. The main module of the running binary, read from its build info, is always application code.
+//
+// Parameters:
+//   - modulePaths: the module paths, e.g. "github.com/acme/shop"
+func SetAppModules(modulePaths ...string) {
+	modules := append([]string(nil), modulePaths...)
+	appModules.Store(&modules)
+	resolvedFrames.clear()
+}
+
+// String returns the name of the origin.
+//
+// Returns:
+//   - string: "app", "dependency" or "stdlib"
+func (o FrameOrigin) String() string {
+	switch o {
+	case OriginApp:
+		return "app"
+	case OriginStdlib:
+		return "stdlib"
+	default:
+		return "dependency"
+	}
+}
+
+// MarshalText encodes the origin as its name.
+//
+// Returns:
+//   - []byte: the name of the origin
+//   - error: always nil
+func (o FrameOrigin) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// Frames resolves the program counters of the stack into frames annotated with their origin.
+//
+// Returns:
+//   - []Frame: the frames, starting with the most recent call
+func (s Stack) Frames() []Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return slices.Clone(resolvedFrames.resolve(s).frames)
+}
+
+// FramesOf returns the frames of the call stack of the innermost error of the chain carrying one.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - []Frame: the frames, or nil if no error of the chain carries a stack
+func FramesOf(err error) []Frame {
+	origin := FindOriginalErrorWithStack(err)
+	if origin == nil {
+		return nil
+	}
+
+	return origin.stack.Frames()
+}
+
+// AppFrame returns the topmost application frame of the error's call stack, the frame most likely
+// to point at the relevant code in dependency-heavy stacks.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - Frame: the topmost application frame
+//   - bool: true if the stack has an application frame
+func AppFrame(err error) (Frame, bool) {
+	for _, frame := range FramesOf(err) {
+		if frame.Origin == OriginApp {
+			return frame, true
+		}
+	}
+
+	return Frame{}, false
+}
+
+// originOf classifies a function by its qualified name, e.g. "github.com/acme/shop/billing.(*Service).Charge".
+func originOf(function string) FrameOrigin {
+	if strings.HasPrefix(function, "main.") || inModule(function, mainModule()) {
+		return OriginApp
+	}
+
+	if modules := appModules.Load(); modules != nil {
+		for _, module := range *modules {
+			if inModule(function, module) {
+				return OriginApp
+			}
+		}
+	}
+
+	// Standard library import paths have no dot in their first element.
+	first, _, _ := strings.Cut(function, "/")
+	if !strings.Contains(function, "/") {
+		first, _, _ = strings.Cut(function, ".")
+	}
+
+	if !strings.Contains(first, ".") {
+		return OriginStdlib
+	}
+
+	return OriginDependency
+}
+
+func inModule(function, module string) bool {
+	return module != "" && (strings.HasPrefix(function, module+".") || strings.HasPrefix(function, module+"/"))
+}
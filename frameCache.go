@@ -0,0 +1,176 @@
+package errors
+
+import (
+	"fmt"
+	"hash/maphash"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultFrameCacheSize is the number of distinct stacks whose resolved frames are cached by default.
+const DefaultFrameCacheSize = 4096
+
+type (
+	// FrameCacheStats reports the effectiveness of the cache of resolved stack frames.
+	FrameCacheStats struct {
+		Hits      uint64 `json:"hits"`
+		Misses    uint64 `json:"misses"`
+		Evictions uint64 `json:"evictions"`
+		Size      int    `json:"size"`
+		Capacity  int    `json:"capacity"`
+	}
+
+	frameCacheEntry struct {
+		pcs       Stack
+		frames    []Frame
+		callStack []string
+	}
+
+	frameCache struct {
+		mu        sync.RWMutex
+		entries   map[uint64]*frameCacheEntry
+		capacity  int
+		hits      atomic.Uint64
+		misses    atomic.Uint64
+		evictions atomic.Uint64
+	}
+)
+
+//nolint:gochecknoglobals
+var (
+	resolvedFrames = &frameCache{capacity: DefaultFrameCacheSize}
+	frameSeed      = maphash.MakeSeed()
+)
+
+// SetFrameCacheSize sets the number of distinct stacks whose resolved frames are cached, so errors created
+// repeatedly at the same site do not resolve their program counters again. It clears the cache.
+//
+// Parameters:
+//   - size: the capacity of the cache; zero or less disables caching
+func SetFrameCacheSize(size int) {
+	resolvedFrames.mu.Lock()
+	defer resolvedFrames.mu.Unlock()
+
+	resolvedFrames.capacity = size
+	resolvedFrames.entries = nil
+}
+
+// FrameCacheMetrics returns the hit, miss and eviction counters and the size of the cache of resolved frames.
+//
+// Returns:
+//   - FrameCacheStats: the cache metrics
+func FrameCacheMetrics() FrameCacheStats {
+	resolvedFrames.mu.RLock()
+	defer resolvedFrames.mu.RUnlock()
+
+	return FrameCacheStats{
+		Hits:      resolvedFrames.hits.Load(),
+		Misses:    resolvedFrames.misses.Load(),
+		Evictions: resolvedFrames.evictions.Load(),
+		Size:      len(resolvedFrames.entries),
+		Capacity:  resolvedFrames.capacity,
+	}
+}
+
+// resolve returns the resolved frames of the stack and their formatted call stack, shared with the cache:
+// callers must not modify them.
+func (c *frameCache) resolve(s Stack) *frameCacheEntry {
+	key := hashStack(s)
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	capacity := c.capacity
+	c.mu.RUnlock()
+
+	if ok && entry.pcs.Equal(s) {
+		c.hits.Add(1)
+
+		return entry
+	}
+
+	c.misses.Add(1)
+
+	entry = resolveStack(s)
+	if capacity <= 0 {
+		return entry
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[uint64]*frameCacheEntry)
+	}
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.capacity {
+		for evicted := range c.entries {
+			delete(c.entries, evicted)
+			c.evictions.Add(1)
+
+			break
+		}
+	}
+
+	c.entries[key] = entry
+
+	return entry
+}
+
+// clear drops the cached frames, e.g. once frame origins change.
+func (c *frameCache) clear() {
+	c.mu.Lock()
+	c.entries = nil
+	c.mu.Unlock()
+}
+
+func resolveStack(s Stack) *frameCacheEntry {
+	entry := &frameCacheEntry{
+		pcs:       slices.Clone(s),
+		frames:    make([]Frame, 0, len(s)),
+		callStack: make([]string, 0, len(s)),
+	}
+	callersFrames := runtime.CallersFrames(s)
+
+	for {
+		frame, more := callersFrames.Next()
+		if frame.Function == "unknown" {
+			break
+		}
+
+		entry.frames = append(entry.frames, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+			Origin:   originOf(frame.Function),
+			// CallersFrames expands inlined calls into frames of their own, without a runtime.Func. Non-Go (cgo)
+			// frames have no runtime.Func either, but neither do they have a file, so they are not reported inlined.
+			Inlined: frame.Func == nil && frame.File != "",
+		})
+		entry.callStack = append(entry.callStack, fmt.Sprintf("%s\n\t%s:%d", frame.Function, frame.File, frame.Line))
+
+		if !more {
+			break
+		}
+	}
+
+	return entry
+}
+
+func hashStack(s Stack) uint64 {
+	var hash maphash.Hash
+
+	hash.SetSeed(frameSeed)
+
+	for _, pc := range s {
+		var buf [8]byte
+		for i := range buf {
+			buf[i] = byte(pc >> (8 * i)) //nolint:gosec
+		}
+
+		_, _ = hash.Write(buf[:]) //nolint:errcheck,revive
+	}
+
+	return hash.Sum64()
+}
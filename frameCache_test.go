@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+// newInlined is small enough to be inlined into its caller.
+func newInlined() error {
+	return errors.NewWithStack("inlined")
+}
+
+//go:noinline
+func callInlined() error {
+	return newInlined()
+}
+
+func TestFramesReportInlinedHelpers(t *testing.T) {
+	errors.EnableDebug(0)
+	t.Cleanup(errors.DisableDebug)
+
+	err := callInlined()
+
+	frames := errors.FramesOf(err)
+	if len(frames) < 2 {
+		t.Fatalf("FramesOf returned %d frames, want at least 2", len(frames))
+	}
+
+	helper := frames[0]
+	if !strings.HasSuffix(helper.Function, ".newInlined") {
+		t.Fatalf("first frame = %s, want newInlined", helper.Function)
+	}
+
+	// Built with inlining disabled (-gcflags=all=-l), newInlined is a frame of its own and is not reported inlined.
+	if !helper.Inlined {
+		t.Log("newInlined was not inlined, its frame is not checked to be reported inlined")
+	}
+
+	if caller := frames[1]; !strings.HasSuffix(caller.Function, ".callInlined") || caller.Inlined {
+		t.Fatalf("second frame = %s (inlined %t), want callInlined, not inlined", caller.Function, caller.Inlined)
+	}
+
+	records := errors.DebugDump()
+	if len(records) == 0 {
+		t.Fatal("DebugDump returned no record")
+	}
+
+	if record := records[len(records)-1]; !strings.HasSuffix(record.Function, ".newInlined") {
+		t.Fatalf("debug record function = %s, want newInlined", record.Function)
+	}
+}
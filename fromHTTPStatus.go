@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// FromHTTPStatus creates an error classified with the predefined error matching an HTTP status
+// (404 to ErrNotFound, 429 to ErrTooManyRequests, 5xx to ErrInternalServerError...), converting an upstream
+// response into the taxonomy with one call. Client errors without a dedicated sentinel (405, 410, 415...) fall back
+// to ErrBadRequest; the original status is recorded in the FieldHTTPStatusCode field.
+//
+// Parameters:
+//   - status: the HTTP status code
+//   - msg: the description of the error; the status text is used when empty
+//
+// Returns:
+//   - error: the classified error, or nil if status does not denote a failure
+func FromHTTPStatus(status int, msg string) error {
+	if status < http.StatusBadRequest {
+		return nil
+	}
+
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	sentinel := SentinelForHTTPStatus(status)
+	if sentinel == nil {
+		sentinel = ErrBadRequest
+	}
+
+	return track(&Error{
+		Description: limitDescription(msg),
+		stack:       stackFor(sentinel, 0),
+		fields:      Fields{FieldHTTPStatusCode: status},
+		error:       sentinel,
+	})
+}
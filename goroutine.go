@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Reporter sends an error to a reporting backend, e.g. datadog.HandleError.
+	Reporter func(ctx context.Context, err error) error
+)
+
+var (
+	goReporter atomic.Pointer[Reporter] //nolint:gochecknoglobals
+	goroutines sync.WaitGroup           //nolint:gochecknoglobals
+)
+
+// SetGoReporter sets the reporter receiving the failures of the goroutines started with Go and GoContext.
+// Without a reporter, failures are logged with the standard logger.
+//
+// Parameters:
+//   - reporter: the reporter; nil restores logging
+func SetGoReporter(reporter Reporter) {
+	if reporter == nil {
+		goReporter.Store(nil)
+
+		return
+	}
+
+	goReporter.Store(&reporter)
+}
+
+// Go runs fn in a new goroutine whose failure is never silently dropped, replacing go func() { _ = fn() }():
+// a returned error or a recovered panic is wrapped with the stack of the launch site and sent to the reporter
+// set with SetGoReporter. Shutdown waits for the goroutines started this way.
+//
+// Parameters:
+//   - fn: the function to run
+func Go(fn func() error) {
+	launch(context.Background(), func(context.Context) error { return fn() }, callers())
+}
+
+// GoContext runs fn in a new goroutine like Go, passing ctx to fn and to the reporter.
+//
+// Parameters:
+//   - ctx: the context of the goroutine
+//   - fn: the function to run
+func GoContext(ctx context.Context, fn func(ctx context.Context) error) {
+	launch(ctx, fn, callers())
+}
+
+func launch(ctx context.Context, fn func(ctx context.Context) error, site *Stack) {
+	goroutines.Add(1)
+
+	go func() {
+		var err error
+
+		defer goroutines.Done()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				cause, ok := recovered.(error)
+				if !ok {
+					cause = fmt.Errorf("%v", recovered) //nolint:err113
+				}
+
+				// The stack captured here runs through the panicking frame.
+				err = track(&Error{Description: "goroutine panicked", stack: callers(), error: cause})
+			}
+
+			if err != nil {
+				reportGoroutine(ctx, track(&Error{stack: site, error: err}))
+			}
+		}()
+
+		err = fn(ctx)
+	}()
+}
+
+func reportGoroutine(ctx context.Context, err error) {
+	if reporter := goReporter.Load(); reporter != nil {
+		_ = (*reporter)(ctx, err) //nolint:errcheck
+
+		return
+	}
+
+	log.Printf("errors: goroutine failed: %+v", err)
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"runtime"
+)
+
+const (
+	// DefaultGoroutineDumpLimit is the maximum size in bytes of a goroutine dump attached to an error.
+	DefaultGoroutineDumpLimit = 64 << 10
+	// GoroutineDumpAttachment is the name of the attachment holding the goroutine dump.
+	GoroutineDumpAttachment = "goroutines.txt"
+)
+
+// AllGoroutinesStack captures the stacks of all running goroutines, truncated to the given size.
+//
+// Parameters:
+//   - limit: the maximum size of the dump in bytes; zero or less uses DefaultGoroutineDumpLimit
+//
+// Returns:
+//   - []byte: the goroutine dump in the format of runtime.Stack
+func AllGoroutinesStack(limit int) []byte {
+	if limit <= 0 {
+		limit = DefaultGoroutineDumpLimit
+	}
+
+	buf := make([]byte, limit)
+	n := runtime.Stack(buf, true)
+
+	return buf[:n]
+}
+
+// WithGoroutineDump attaches a size-capped dump of all goroutines to the error as the GoroutineDumpAttachment
+// attachment, so deadlock and timeout errors can be analyzed postmortem.
+//
+// Parameters:
+//   - err: the error to annotate; if nil, nil is returned
+//
+// Returns:
+//   - error: the error carrying the goroutine dump
+func WithGoroutineDump(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return Annotate(err, WithAttachment(GoroutineDumpAttachment, AllGoroutinesStack(DefaultGoroutineDumpLimit), "text/plain"))
+}
+
+// GoroutineDump returns the goroutine dump attached anywhere in the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - []byte: the outermost goroutine dump, or nil if none is attached
+func GoroutineDump(err error) []byte {
+	attachment, _ := AttachmentOf(err, GoroutineDumpAttachment)
+
+	return attachment.Data
+}
@@ -0,0 +1,200 @@
+// Package graphqlerrors converts errors of github.com/ceearrashee/errors into gqlerror-compatible GraphQL errors
+// and provides an error presenter and a recover function for gqlgen servers, without importing gqlgen itself:
+//
+//	srv.SetErrorPresenter(graphqlerrors.Presenter(graphqlerrors.PathFunc(graphql.GetPath), graphqlerrors.Report(datadog.HandleError)))
+//	srv.SetRecoverFunc(graphqlerrors.Recover(graphqlerrors.Report(datadog.HandleError)))
+package graphqlerrors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// ExtensionCode is the extension holding the registered error code.
+	ExtensionCode = "code"
+	// ExtensionReferenceCode is the extension holding the reference code used to find the error in logs.
+	ExtensionReferenceCode = errors.FieldReferenceCode
+	// ExtensionFingerprint is the extension holding the fingerprint grouping errors of the same kind.
+	ExtensionFingerprint = "fingerprint"
+	// ExtensionFields is the extension holding the structured fields of the error.
+	ExtensionFields = "fields"
+
+	// internalMessage is the message returned to clients for recovered panics.
+	internalMessage = "internal system error"
+)
+
+type (
+	// Option customizes the presenter and recover functions.
+	Option func(*config)
+
+	config struct {
+		report func(ctx context.Context, err error) error
+		path   func(ctx context.Context) ast.Path
+	}
+
+	// reportedPanic marks recovered panics already sent to the reporter, since gqlgen passes
+	// the result of the recover function through the presenter as well.
+	reportedPanic struct {
+		error
+	}
+)
+
+// Report sets the reporter every presented error and recovered panic is sent to, e.g. datadog.HandleError.
+//
+// Parameters:
+//   - report: the reporter to call
+//
+// Returns:
+//   - Option: the option applying the reporter
+func Report(report func(ctx context.Context, err error) error) Option {
+	return func(c *config) {
+		c.report = report
+	}
+}
+
+// PathFunc sets the function resolving the GraphQL path of the field being resolved, typically graphql.GetPath.
+//
+// Parameters:
+//   - path: the function returning the path from the request context
+//
+// Returns:
+//   - Option: the option applying the path resolver
+func PathFunc(path func(ctx context.Context) ast.Path) Option {
+	return func(c *config) {
+		c.path = path
+	}
+}
+
+// ToGQLError converts an error into a GraphQL error whose extensions carry the code, reference code,
+// fingerprint and fields of the error. Path and locations of a gqlerror.Error found in the chain are kept.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - *gqlerror.Error: the GraphQL error, or nil if err is nil
+func ToGQLError(err error) *gqlerror.Error {
+	if err == nil {
+		return nil
+	}
+
+	out := &gqlerror.Error{Err: err, Message: errors.ScrubMessage(err.Error())}
+
+	if gqlErr, ok := errors.AsType[*gqlerror.Error](err); ok {
+		out.Path = gqlErr.Path
+		out.Locations = gqlErr.Locations
+		out.Rule = gqlErr.Rule
+
+		// An error presented as is keeps its own message and unwraps to the resolver error, if any.
+		if gqlErr == err { //nolint:errorlint
+			out.Message = errors.ScrubMessage(gqlErr.Message)
+
+			if gqlErr.Err != nil {
+				out.Err = gqlErr.Err
+			}
+		}
+
+		for key, value := range gqlErr.Extensions {
+			setExtension(out, key, value)
+		}
+	}
+
+	if code := errors.CodeOf(out.Err); code != "" {
+		setExtension(out, ExtensionCode, string(code))
+	}
+
+	setExtension(out, ExtensionReferenceCode, errors.AutoCode(out.Err))
+	setExtension(out, ExtensionFingerprint, errors.Normalize(out.Err).Fingerprint())
+
+	if fields := errors.ScrubFields(errors.FieldsOf(out.Err)); len(fields) > 0 {
+		setExtension(out, ExtensionFields, fields)
+	}
+
+	return out
+}
+
+// Presenter returns a gqlgen error presenter converting resolver errors with ToGQLError and sending them to the reporter.
+//
+// Parameters:
+//   - opts: options setting the reporter and path resolver
+//
+// Returns:
+//   - func(context.Context, error) *gqlerror.Error: the presenter, assignable to handler.Server.SetErrorPresenter
+func Presenter(opts ...Option) func(ctx context.Context, err error) *gqlerror.Error {
+	cfg := newConfig(opts)
+
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := ToGQLError(err)
+		if gqlErr == nil {
+			return nil
+		}
+
+		if gqlErr.Path == nil && cfg.path != nil {
+			gqlErr.Path = cfg.path(ctx)
+		}
+
+		if _, reported := errors.AsType[reportedPanic](gqlErr.Err); !reported && cfg.report != nil {
+			_ = cfg.report(ctx, gqlErr.Err)
+		}
+
+		return gqlErr
+	}
+}
+
+// Recover returns a gqlgen recover function turning panics into errors with a call stack. The panic is sent to
+// the reporter while the client only receives a generic message, as gqlgen does by default.
+//
+// Parameters:
+//   - opts: options setting the reporter
+//
+// Returns:
+//   - func(context.Context, any) error: the recover function, assignable to handler.Server.SetRecoverFunc
+func Recover(opts ...Option) func(ctx context.Context, recovered any) error {
+	cfg := newConfig(opts)
+
+	return func(ctx context.Context, recovered any) error {
+		err, ok := recovered.(error)
+		if !ok {
+			err = fmt.Errorf("%v", recovered) //nolint:err113
+		}
+
+		err = errors.WrapSkipping(err, 1, "graphql resolver panic")
+
+		if cfg.report != nil {
+			_ = cfg.report(ctx, err)
+		}
+
+		return &gqlerror.Error{
+			Err:        reportedPanic{err},
+			Message:    internalMessage,
+			Extensions: map[string]any{ExtensionReferenceCode: errors.AutoCode(err)},
+		}
+	}
+}
+
+func (r reportedPanic) Unwrap() error {
+	return r.error
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+func setExtension(err *gqlerror.Error, key string, value any) {
+	if err.Extensions == nil {
+		err.Extensions = make(map[string]any)
+	}
+
+	err.Extensions[key] = value
+}
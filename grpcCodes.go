@@ -0,0 +1,153 @@
+package errors
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+)
+
+//nolint:gochecknoglobals
+var (
+	grpcCodesMu sync.RWMutex
+
+	// grpcCodeSentinels maps the names of gRPC codes, as returned by codes.Code.String(), onto sentinels.
+	grpcCodeSentinels = map[string]error{
+		"Canceled":           ErrCanceled,
+		"Unknown":            ErrInternalServerError,
+		"InvalidArgument":    ErrBadRequest,
+		"DeadlineExceeded":   ErrTimeout,
+		"NotFound":           ErrNotFound,
+		"AlreadyExists":      ErrConflict,
+		"PermissionDenied":   ErrForbiddenAction,
+		"ResourceExhausted":  ErrTooManyRequests,
+		"FailedPrecondition": ErrPreconditionFailed,
+		"Aborted":            ErrConflict,
+		"OutOfRange":         ErrBadRequest,
+		"Unimplemented":      ErrInternalServerError,
+		"Internal":           ErrInternalServerError,
+		"Unavailable":        ErrInternalServerError,
+		"DataLoss":           ErrInternalServerError,
+		"Unauthenticated":    ErrUnauthorized,
+	}
+
+	// registeredGRPCCodes lists the codes mapped with RegisterGRPCCodeMapping, in registration order.
+	registeredGRPCCodes []string
+)
+
+// RegisterGRPCCodeMapping maps a gRPC code onto a sentinel, replacing the default mapping of the code, e.g. to
+// classify Unavailable with an application-specific retryable sentinel. It is the single gRPC table of the module:
+// it applies to grpcerrors, clouderrors and to the gRPC code of the definition of the sentinel (see GRPCCodeOf).
+//
+// Parameters:
+//   - code: the name of the gRPC code, as returned by codes.Code.String(), e.g. "Unavailable"
+//   - sentinel: the sentinel errors received with the code are classified with; nil restores ErrInternalServerError
+func RegisterGRPCCodeMapping(code string, sentinel error) {
+	grpcCodesMu.Lock()
+	defer grpcCodesMu.Unlock()
+
+	if sentinel == nil {
+		sentinel = ErrInternalServerError
+	}
+
+	grpcCodeSentinels[code] = sentinel
+	registeredGRPCCodes = append(slices.DeleteFunc(registeredGRPCCodes, func(name string) bool {
+		return name == code
+	}), code)
+}
+
+// SentinelForGRPCCode returns the sentinel a gRPC code maps onto: NotFound maps to ErrNotFound, DeadlineExceeded
+// to ErrTimeout, Unauthenticated to ErrUnauthorized, and so on, including the mappings registered with
+// RegisterGRPCCodeMapping.
+//
+// Parameters:
+//   - code: the name of the gRPC code, as returned by codes.Code.String()
+//
+// Returns:
+//   - error: the sentinel, ErrInternalServerError for unknown codes, or nil for "OK"
+func SentinelForGRPCCode(code string) error {
+	if code == "OK" {
+		return nil
+	}
+
+	grpcCodesMu.RLock()
+	defer grpcCodesMu.RUnlock()
+
+	if sentinel, ok := grpcCodeSentinels[code]; ok {
+		return sentinel
+	}
+
+	return ErrInternalServerError
+}
+
+// GRPCCodeOf returns the name of the gRPC code a definition is sent with: its GRPCCode when set, else the code
+// mapped onto its sentinel with RegisterGRPCCodeMapping (the latest registration wins), else the code
+// conventionally matching its HTTP status (see GRPCCodeForHTTPStatus).
+//
+// Parameters:
+//   - def: the definition
+//
+// Returns:
+//   - string: the name of the gRPC code, as returned by codes.Code.String(), or "" if def has no HTTP status
+func GRPCCodeOf(def ErrorDefinition) string {
+	if def.GRPCCode != "" {
+		return def.GRPCCode
+	}
+
+	if def.Err != nil {
+		grpcCodesMu.RLock()
+		defer grpcCodesMu.RUnlock()
+
+		for _, code := range slices.Backward(registeredGRPCCodes) {
+			if matchesSentinel(grpcCodeSentinels[code], def.Err) {
+				return code
+			}
+		}
+	}
+
+	return GRPCCodeForHTTPStatus(def.HTTPStatus)
+}
+
+// GRPCCodeForHTTPStatus returns the name of the gRPC code conventionally matching an HTTP status: 404 maps to
+// NotFound, 429 to ResourceExhausted, 504 to DeadlineExceeded, any other 5xx to Internal, and so on.
+//
+// Parameters:
+//   - status: the HTTP status code
+//
+// Returns:
+//   - string: the name of the gRPC code, as returned by codes.Code.String(), or "" if status is 0
+func GRPCCodeForHTTPStatus(status int) string {
+	switch status {
+	case 0:
+		return ""
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return "InvalidArgument"
+	case http.StatusUnauthorized:
+		return "Unauthenticated"
+	case http.StatusForbidden:
+		return "PermissionDenied"
+	case http.StatusNotFound:
+		return "NotFound"
+	case http.StatusConflict:
+		return "AlreadyExists"
+	case http.StatusPaymentRequired, http.StatusPreconditionFailed:
+		return "FailedPrecondition"
+	case http.StatusTooManyRequests:
+		return "ResourceExhausted"
+	case StatusClientClosedRequest:
+		return "Canceled"
+	case http.StatusNotImplemented:
+		return "Unimplemented"
+	case http.StatusServiceUnavailable:
+		return "Unavailable"
+	case http.StatusGatewayTimeout:
+		return "DeadlineExceeded"
+	case http.StatusInternalServerError:
+		return "Internal"
+	}
+
+	if status >= http.StatusInternalServerError {
+		return "Internal"
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,152 @@
+package grpcerrors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// ProblemContentType is the media type of the problem details written by GatewayErrorHandler.
+	ProblemContentType = "application/problem+json"
+	// MetadataReferenceCode is the ErrorInfo metadata key holding the reference code of the error.
+	MetadataReferenceCode = errors.FieldReferenceCode
+	// MetadataInstanceID is the ErrorInfo metadata key holding the instance ID of the error.
+	MetadataInstanceID = "instance_id"
+)
+
+type (
+	// Problem is the RFC 9457 problem details body written by GatewayErrorHandler,
+	// matching the schema generated by the openapigen package.
+	Problem struct {
+		Type          string           `json:"type,omitempty"`
+		Title         string           `json:"title,omitempty"`
+		Status        int              `json:"status"`
+		Detail        string           `json:"detail,omitempty"`
+		Instance      string           `json:"instance,omitempty"`
+		Code          errors.ErrorCode `json:"code"`
+		ReferenceCode string           `json:"reference_code,omitempty"`
+	}
+)
+
+// StatusOf converts err into a gRPC status for the server side of a gateway: the code of its registered
+// definition (see errors.GRPCCodeOf), the registered (client-safe) message, and an errdetails.ErrorInfo detail
+// whose reason is the error code and whose metadata holds the reference and instance IDs, read back by
+// GatewayErrorHandler.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - *status.Status: the status, or nil if err is nil
+func StatusOf(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	def, ok := errors.DefinitionOf(err)
+	if !ok {
+		def, _ = errors.DefinitionOf(errors.ErrInternalServerError)
+	}
+
+	code := codeByName(errors.GRPCCodeOf(def))
+
+	metadata := map[string]string{MetadataReferenceCode: errors.AutoCode(err)}
+	if id := errors.InstanceID(err); id != "" {
+		metadata[MetadataInstanceID] = id
+	}
+
+	st := status.New(code, def.Message)
+
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: string(def.Code), Metadata: metadata}); detailErr == nil {
+		return detailed
+	}
+
+	return st
+}
+
+// GatewayErrorHandler writes the errors of a grpc-gateway proxy as problem details (application/problem+json):
+// the HTTP status of the registered definition matching the ErrorInfo reason set by StatusOf, or else of the sentinel
+// the gRPC code maps onto (see SentinelOf), with the status message as detail and the reference code as extension.
+// The package does not depend on grpc-gateway: instantiate the handler with the gateway types to get a
+// runtime.ErrorHandlerFunc:
+//
+//	mux := runtime.NewServeMux(runtime.WithErrorHandler(grpcerrors.GatewayErrorHandler[*runtime.ServeMux, runtime.Marshaler]))
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - mux: the gateway mux, unused
+//   - marshaler: the gateway marshaler, unused since problem details are always JSON
+//   - w: the response writer
+//   - r: the request
+//   - err: the error returned by the gRPC call
+func GatewayErrorHandler[Mux, Marshaler any](_ context.Context, _ Mux, _ Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	problem := ProblemOf(err)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem) //nolint:errcheck,errchkjson
+}
+
+// ProblemOf builds the problem details of an error received from a gRPC call, as written by GatewayErrorHandler.
+//
+// Parameters:
+//   - err: the error returned by the gRPC call
+//
+// Returns:
+//   - Problem: the problem details
+func ProblemOf(err error) Problem {
+	st := status.Convert(err)
+
+	var info *errdetails.ErrorInfo
+
+	for _, detail := range st.Details() {
+		if errorInfo, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = errorInfo
+
+			break
+		}
+	}
+
+	def, ok := errors.ErrorDefinition{}, false
+	if info != nil {
+		def, ok = errors.DefinitionByCode(errors.ErrorCode(info.GetReason()))
+	}
+
+	if !ok {
+		def, _ = errors.DefinitionOf(SentinelOf(st.Code()))
+	}
+
+	problem := Problem{
+		Title:  def.Message,
+		Status: def.HTTPStatus,
+		Detail: st.Message(),
+		Code:   def.Code,
+	}
+
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+
+	if problem.Detail == problem.Title {
+		problem.Detail = ""
+	}
+
+	if info != nil {
+		problem.ReferenceCode = info.GetMetadata()[MetadataReferenceCode]
+		problem.Instance = info.GetMetadata()[MetadataInstanceID]
+	}
+
+	if problem.Instance == "" {
+		// Errors of this package converted by the gateway itself still carry their instance ID.
+		problem.Instance = errors.InstanceID(err)
+	}
+
+	return problem
+}
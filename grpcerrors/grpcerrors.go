@@ -0,0 +1,107 @@
+// Package grpcerrors converts gRPC status codes into the predefined error taxonomy of github.com/ceearrashee/errors,
+// for gRPC clients classifying failures without a full interceptor:
+//
+//	if st, ok := status.FromError(err); ok {
+//		return grpcerrors.FromGRPCCode(st.Code(), st.Message())
+//	}
+//
+// GatewayErrorHandler renders the errors of a grpc-gateway proxy as problem details, reading the error code that
+// StatusOf stores in the status details on the server side.
+package grpcerrors
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/ceearrashee/errors"
+)
+
+// FieldCode is the field holding the name of the gRPC code the error was created from, e.g. "NotFound".
+const FieldCode = "grpc.code"
+
+// RegisterCodeMapping maps a gRPC code onto a sentinel, replacing the default mapping of the code,
+// e.g. to classify codes.Unavailable with an application-specific retryable sentinel. It registers the mapping
+// with errors.RegisterGRPCCodeMapping, so clouderrors and the catalog follow it as well.
+//
+// Parameters:
+//   - code: the gRPC code
+//   - sentinel: the sentinel errors created from the code are classified with; nil restores ErrInternalServerError
+func RegisterCodeMapping(code codes.Code, sentinel error) {
+	errors.RegisterGRPCCodeMapping(code.String(), sentinel)
+}
+
+// SentinelOf returns the sentinel a gRPC code maps onto (see errors.SentinelForGRPCCode).
+//
+// Parameters:
+//   - code: the gRPC code
+//
+// Returns:
+//   - error: the sentinel, ErrInternalServerError for unknown codes, or nil for codes.OK
+func SentinelOf(code codes.Code) error {
+	return errors.SentinelForGRPCCode(code.String())
+}
+
+// CodeOf returns the gRPC code an error is sent with: the code of its registered definition (see errors.GRPCCodeOf),
+// standard library errors such as context.Canceled being classified first (see errors.Classify). Connect and Twirp
+// codes derive from it.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - codes.Code: the code, codes.Internal if err is not classified, or codes.OK if err is nil
+func CodeOf(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	def, ok := errors.DefinitionOf(errors.Classify(err))
+	if !ok {
+		return codes.Internal
+	}
+
+	return codeByName(errors.GRPCCodeOf(def))
+}
+
+// codeByName returns the gRPC code with the given name, as returned by codes.Code.String() and stored in
+// ErrorDefinition.GRPCCode, or codes.Internal for unknown names.
+func codeByName(name string) codes.Code {
+	if code, ok := codesByName()[name]; ok {
+		return code
+	}
+
+	return codes.Internal
+}
+
+// codesByName indexes the gRPC codes by name.
+var codesByName = sync.OnceValue(func() map[string]codes.Code { //nolint:gochecknoglobals
+	byName := make(map[string]codes.Code, codes.Unauthenticated+1)
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		byName[code.String()] = code
+	}
+
+	return byName
+})
+
+// FromGRPCCode creates an error classified with the sentinel the gRPC code maps onto (NotFound to ErrNotFound,
+// DeadlineExceeded to ErrTimeout...), recording the code in the FieldCode field.
+//
+// Parameters:
+//   - code: the gRPC code of the failed call
+//   - msg: the description of the error, typically the status message; the code name is used when empty
+//
+// Returns:
+//   - error: the classified error, or nil for codes.OK
+func FromGRPCCode(code codes.Code, msg string) error {
+	sentinel := SentinelOf(code)
+	if sentinel == nil {
+		return nil
+	}
+
+	if msg == "" {
+		msg = code.String()
+	}
+
+	return errors.Annotate(errors.WrapSkipping(sentinel, 1, msg), errors.WithField(FieldCode, code.String()))
+}
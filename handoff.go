@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// handoffKind marks errors recording where an error crossed a goroutine boundary.
+type handoffKind uint8
+
+const (
+	handoffNone handoffKind = iota
+	handoffProducer
+	handoffConsumer
+)
+
+// Handoff marks err as crossing a channel or queue boundary to another goroutine, recording the producer's
+// call stack. The consumer calls Resume on receipt, so %+v shows both stacks stitched together.
+//
+//	results <- errors.Handoff(err)
+//	...
+//	return errors.Resume(<-results)
+//
+// Parameters:
+//   - err: the error being handed off
+//
+// Returns:
+//   - error: err with the producer stack segment, or nil if err is nil
+func Handoff(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return track(&Error{
+		stack:   callers(),
+		error:   err,
+		handoff: handoffProducer,
+	})
+}
+
+// Resume marks err as received from another goroutine after a Handoff, recording the consumer's call stack.
+//
+// Parameters:
+//   - err: the error received from the other goroutine
+//
+// Returns:
+//   - error: err with the consumer stack segment, or nil if err is nil
+func Resume(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return track(&Error{
+		stack:   callers(),
+		error:   err,
+		handoff: handoffConsumer,
+	})
+}
+
+// writeHandoffStacks writes the stack segments of a chain crossing goroutines, most recent first:
+// each Resume and Handoff segment followed by the stack of the original error.
+// It reports false, writing nothing, when the chain contains no handoff.
+func writeHandoffStacks(w io.Writer, err error) bool {
+	var segments []*Error
+
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.handoff != handoffNone { //nolint:errorlint
+			segments = append(segments, frameworkErr)
+		}
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if origin := FindOriginalErrorWithStack(err); origin != nil && origin.handoff == handoffNone {
+		segments = append(segments, origin)
+	}
+
+	for _, segment := range segments {
+		switch segment.handoff {
+		case handoffConsumer:
+			_, _ = fmt.Fprint(w, "\n--- resumed ---") //nolint:errcheck,revive
+		case handoffProducer:
+			_, _ = fmt.Fprint(w, "\n--- handed off ---") //nolint:errcheck,revive
+		default:
+			_, _ = fmt.Fprint(w, "\n--- created ---") //nolint:errcheck,revive
+		}
+
+		for _, frame := range segment.GetCallStack() {
+			_, _ = fmt.Fprintf(w, "\n%s", frame) //nolint:errcheck,revive
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HTTPError describes the failed response of an outbound HTTP call. WrapHTTPResponse places it in the chain,
+// so middle layers can branch on the upstream status without parsing messages or fields.
+type HTTPError struct {
+	// Status is the status code of the response.
+	Status int
+	// Method is the method of the request, if known.
+	Method string
+	// URL is the redacted URL of the request, if known.
+	URL string
+	// BodySnippet is the beginning of the response body, up to the configured ResponseBodyLimit.
+	BodySnippet string
+	// Header holds the response headers selected with ResponseHeaders.
+	Header http.Header
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// Error returns the message of the error returned by the call, or describes the unexpected status.
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("unexpected HTTP status %d", e.Status)
+}
+
+// Unwrap returns the error returned by the call.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// AsHTTPError finds the HTTPError recorded in the chain by WrapHTTPResponse.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - *HTTPError: the upstream response details, or nil if none is found
+//   - bool: true if the chain contains an HTTPError
+func AsHTTPError(err error) (*HTTPError, bool) {
+	return AsType[*HTTPError](err)
+}
+
+func newHTTPError(err error, resp *http.Response, body string, headers map[string]string) *HTTPError {
+	httpErr := &HTTPError{Status: resp.StatusCode, BodySnippet: body, Err: err}
+
+	if resp.Request != nil {
+		httpErr.Method = resp.Request.Method
+
+		if resp.Request.URL != nil {
+			httpErr.URL = resp.Request.URL.Redacted()
+		}
+	}
+
+	if len(headers) > 0 {
+		httpErr.Header = make(http.Header, len(headers))
+		for name, value := range headers {
+			httpErr.Header.Set(name, value)
+		}
+	}
+
+	return httpErr
+}
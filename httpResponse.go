@@ -0,0 +1,206 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultResponseBodyLimit is the number of response body bytes recorded by WrapHTTPResponse.
+	DefaultResponseBodyLimit = 1024
+
+	// FieldHTTPStatusCode is the field holding the status code of an upstream response.
+	FieldHTTPStatusCode = "http.status_code"
+	// FieldHTTPResponseBody is the field holding the truncated body of an upstream response.
+	FieldHTTPResponseBody = "http.response.body"
+	// FieldHTTPResponseHeaders is the field holding the selected headers of an upstream response.
+	FieldHTTPResponseHeaders = "http.response.headers"
+	// FieldHTTPMethod is the field holding the method of an outbound request.
+	FieldHTTPMethod = "http.method"
+	// FieldHTTPURL is the field holding the redacted URL of an outbound request.
+	FieldHTTPURL = "http.url"
+)
+
+type (
+	// HTTPResponseOption customizes the details recorded by WrapHTTPResponse.
+	HTTPResponseOption func(*httpResponseConfig)
+
+	httpResponseConfig struct {
+		bodyLimit int
+		headers   []string
+		mapStatus bool
+	}
+)
+
+// ResponseBodyLimit sets the maximum number of body bytes recorded from the response.
+//
+// Parameters:
+//   - limit: the maximum number of bytes; zero disables body recording
+//
+// Returns:
+//   - HTTPResponseOption: the option applying the limit
+func ResponseBodyLimit(limit int) HTTPResponseOption {
+	return func(c *httpResponseConfig) {
+		c.bodyLimit = limit
+	}
+}
+
+// ResponseHeaders sets the names of the response headers recorded into fields.
+//
+// Parameters:
+//   - names: the canonical or non-canonical header names to record
+//
+// Returns:
+//   - HTTPResponseOption: the option applying the header selection
+func ResponseHeaders(names ...string) HTTPResponseOption {
+	return func(c *httpResponseConfig) {
+		c.headers = names
+	}
+}
+
+// MapResponseStatus controls whether the upstream status is mapped onto a predefined error
+// (for example 404 to ErrNotFound), so callers can pass the classification through with Is.
+//
+// Parameters:
+//   - enabled: true to map statuses (the default), false to keep the error unclassified
+//
+// Returns:
+//   - HTTPResponseOption: the option applying the mapping behavior
+func MapResponseStatus(enabled bool) HTTPResponseOption {
+	return func(c *httpResponseConfig) {
+		c.mapStatus = enabled
+	}
+}
+
+// WrapHTTPResponse wraps the failure of an outbound HTTP call, recording the status code, a truncated body
+// and selected headers of the response into fields and into an HTTPError (see AsHTTPError),
+// and classifying the error from the upstream status. The origin chain of the X-Error-Origin header is recorded
+// as FieldErrorOrigin (see ErrorOriginOf).
+// The consumed part of the body is restored, so the caller can still read the full response body.
+//
+// Parameters:
+//   - err: the error returned by the call; may be nil when only the status denotes a failure
+//   - resp: the response of the call; may be nil
+//   - description: a description providing context for the error
+//   - opts: options customizing the recorded details
+//
+// Returns:
+//   - error: the wrapped error, or nil if err is nil and the response does not denote a failure
+func WrapHTTPResponse(err error, resp *http.Response, description string, opts ...HTTPResponseOption) error {
+	if err == nil && (resp == nil || resp.StatusCode < http.StatusBadRequest) {
+		return nil
+	}
+
+	cfg := httpResponseConfig{
+		bodyLimit: DefaultResponseBodyLimit,
+		headers:   []string{"Content-Type", "Retry-After", "X-Request-Id"},
+		mapStatus: true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if resp == nil {
+		return track(&Error{Description: limitDescription(description), stack: stackFor(err, 0), error: err})
+	}
+
+	fields := Fields{FieldHTTPStatusCode: resp.StatusCode}
+
+	body := readBodySnippet(resp, cfg.bodyLimit)
+	if body != "" {
+		fields[FieldHTTPResponseBody] = body
+	}
+
+	headers := selectHeaders(resp.Header, cfg.headers)
+	if len(headers) > 0 {
+		fields[FieldHTTPResponseHeaders] = headers
+	}
+
+	if origin := parseErrorOrigin(resp.Header.Get(HeaderErrorOrigin)); len(origin) > 0 {
+		fields[FieldErrorOrigin] = strings.Join(origin, ",")
+	}
+
+	var cause error = newHTTPError(err, resp, body, headers)
+
+	if sentinel := SentinelForHTTPStatus(resp.StatusCode); cfg.mapStatus && sentinel != nil {
+		cause = fmt.Errorf("%w: %w", sentinel, cause)
+	}
+
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(cause, 0),
+		fields:      fields,
+		error:       cause,
+	})
+}
+
+func readBodySnippet(resp *http.Response, limit int) string {
+	if limit <= 0 || resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, int64(limit))) //nolint:errcheck
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(snippet), resp.Body), resp.Body}
+
+	return string(snippet)
+}
+
+func selectHeaders(header http.Header, names []string) map[string]string {
+	selected := make(map[string]string, len(names))
+
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			selected[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+
+	return selected
+}
+
+// SentinelForHTTPStatus returns the predefined error matching an HTTP status: 404 maps to ErrNotFound, 429 to
+// ErrTooManyRequests, 408 and 504 to ErrTimeout, any other 5xx to ErrInternalServerError, and so on. It is the
+// single table used by WrapHTTPResponse, FromHTTPStatus and the integration packages.
+//
+// Parameters:
+//   - status: the HTTP status code
+//
+// Returns:
+//   - error: the matching predefined error, or nil if the status has no dedicated sentinel
+func SentinelForHTTPStatus(status int) error {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusPaymentRequired:
+		return ErrPaymentError
+	case http.StatusForbidden:
+		return ErrForbiddenAction
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case StatusClientClosedRequest:
+		return ErrCanceled
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrTimeout
+	}
+
+	if status >= http.StatusInternalServerError {
+		return ErrInternalServerError
+	}
+
+	return nil
+}
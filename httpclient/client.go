@@ -0,0 +1,107 @@
+// Package httpclient wraps outbound HTTP calls so transport failures, timeouts and 4xx/5xx statuses
+// surface as classified errors with consistent metadata.
+package httpclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FieldTimeout is the field set to true when the call failed because of a timeout.
+	FieldTimeout = "http.timeout"
+	// FieldDeadline is the field holding the context deadline of a timed out call.
+	FieldDeadline = "http.deadline"
+)
+
+type (
+	// Client performs outbound HTTP calls, converting failures into classified errors.
+	Client struct {
+		// HTTPClient is the underlying client; http.DefaultClient is used when nil.
+		HTTPClient *http.Client
+		// Options customize the response details recorded for 4xx and 5xx responses.
+		Options []errors.HTTPResponseOption
+	}
+)
+
+// Do sends the request with http.DefaultClient; see Client.Do.
+//
+// Parameters:
+//   - ctx: the context of the call, bounding its duration
+//   - req: the request to send
+//
+// Returns:
+//   - *http.Response: the response, also returned for 4xx and 5xx statuses
+//   - error: a classified error for transport failures, timeouts and 4xx/5xx statuses
+func Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return (&Client{}).do(ctx, req, 1)
+}
+
+// Do sends the request bound to ctx. Transport failures and timeouts are wrapped with the request method
+// and redacted URL in fields (timeouts are also flagged with FieldTimeout and keep context.DeadlineExceeded in the chain);
+// 4xx and 5xx responses are classified from their status with a body snippet, see errors.WrapHTTPResponse.
+// For those statuses the response is returned alongside the error and its body must still be closed. 1xx and 3xx
+// responses are not failures: http.Client follows redirects, so a 3xx only reaches the caller when redirects are
+// disabled by CheckRedirect or for 304 Not Modified, and it is returned without error like a 2xx.
+//
+// Parameters:
+//   - ctx: the context of the call, bounding its duration
+//   - req: the request to send
+//
+// Returns:
+//   - *http.Response: the response, also returned for 4xx and 5xx statuses
+//   - error: a classified error for transport failures, timeouts and 4xx/5xx statuses
+func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.do(ctx, req, 1)
+}
+
+// do sends the request; skip is the number of frames between do and the caller of the entry point, excluded
+// from the stack of transport errors.
+func (c *Client) do(ctx context.Context, req *http.Request, skip int) (*http.Response, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req = req.WithContext(ctx)
+	description := req.Method + " " + req.URL.Redacted()
+	fields := errors.Fields{
+		errors.FieldHTTPMethod: req.Method,
+		errors.FieldHTTPURL:    req.URL.Redacted(),
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if isTimeout(ctx, err) {
+			fields[FieldTimeout] = true
+
+			if deadline, ok := ctx.Deadline(); ok {
+				fields[FieldDeadline] = deadline.Format(time.RFC3339Nano)
+			}
+		}
+
+		return nil, errors.Annotate(errors.WrapSkipping(err, 1+skip, description), errors.WithFields(fields))
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	statusErr := errors.WrapHTTPResponse(nil, resp, description, c.Options...)
+
+	return resp, errors.Annotate(statusErr, errors.WithFields(fields))
+}
+
+func isTimeout(ctx context.Context, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return true
+	}
+
+	netErr, ok := errors.AsType[net.Error](err)
+
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,53 @@
+// Package httpdebug exposes live error statistics over HTTP for operational visibility,
+// in the spirit of expvar and net/http/pprof.
+//
+//	mux.Handle("/debug/errors", httpdebug.Handler())
+package httpdebug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ceearrashee/errors"
+)
+
+type (
+	// report is the payload served by the handler.
+	report struct {
+		errors.Stats
+
+		// Created lists the recent error creations when the package debug mode is enabled.
+		Created []errors.DebugRecord `json:"created,omitempty"`
+		// NilWraps lists the call sites wrapping a nil error when the package debug mode is enabled.
+		NilWraps []errors.NilWrap `json:"nil_wraps,omitempty"`
+	}
+)
+
+// Handler returns an HTTP handler serving, as JSON, the counters of observed errors by code and fingerprint,
+// the most recent exemplar of each fingerprint with its stack, and, if debug mode is enabled, the creation log
+// and the call sites wrapping nil errors.
+// A POST request with the "reset" query parameter clears the counters.
+//
+// Returns:
+//   - http.Handler: the debug handler
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Query().Has("reset") {
+			errors.ResetStats()
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		_ = encoder.Encode(report{
+			Stats:    errors.Snapshot(),
+			Created:  errors.DebugDump(),
+			NilWraps: errors.NilWraps(),
+		}) //nolint:errcheck,errchkjson
+	})
+}
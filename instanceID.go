@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"crypto/rand"
+	"sync/atomic"
+)
+
+// crockfordAlphabet is the Crockford base32 alphabet used to encode ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var instanceIDs atomic.Bool //nolint:gochecknoglobals
+
+// EnableInstanceIDs turns on the generation of a unique instance ID (a ULID by default, see Provider.NewID)
+// for every error created by this package, so a single occurrence can be traced end-to-end across responses,
+// logs and spans.
+func EnableInstanceIDs() {
+	instanceIDs.Store(true)
+}
+
+// DisableInstanceIDs turns off the generation of instance IDs.
+func DisableInstanceIDs() {
+	instanceIDs.Store(false)
+}
+
+// InstanceID returns the instance ID of the error occurrence, as assigned to the outermost error of the chain
+// created while instance IDs were enabled.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - string: the instance ID, or an empty string if none was assigned
+func InstanceID(err error) string {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.instanceID != "" { //nolint:errorlint
+			return frameworkErr.instanceID
+		}
+	}
+
+	return ""
+}
+
+// newULID returns a ULID: a 48-bit millisecond timestamp from the provider's clock followed by 80 random bits,
+// encoded in 26 Crockford base32 characters so identifiers sort by creation time.
+func newULID() string {
+	var b [16]byte
+
+	ms := uint64(now().UnixMilli()) //nolint:gosec
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+
+	_, _ = rand.Read(b[6:]) //nolint:errcheck // crypto/rand.Read never fails.
+
+	var out [26]byte
+
+	// 128 bits are encoded as 26 groups of 5 bits, the first group holding only the 3 leading bits.
+	for i := range out {
+		bit := i*5 - 2
+
+		var group byte
+
+		for j := range 5 {
+			if pos := bit + j; pos >= 0 {
+				group = group<<1 | b[pos/8]>>(7-pos%8)&1
+			}
+		}
+
+		out[i] = crockfordAlphabet[group]
+	}
+
+	return string(out[:])
+}
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"encoding/json"
+)
+
+type (
+	// jsonError is the serialized representation of an error.
+	jsonError struct {
+		Version     int                  `json:"version,omitempty"`
+		Message     string               `json:"message"`
+		Code        string               `json:"reference_code,omitempty"`
+		Fingerprint string               `json:"fingerprint,omitempty"`
+		InstanceID  string               `json:"instance_id,omitempty"`
+		Description string               `json:"description,omitempty"`
+		Template    string               `json:"template,omitempty"`
+		Fields      Fields               `json:"fields,omitempty"`
+		Tags        []string             `json:"tags,omitempty"`
+		Upstream    *Upstream            `json:"upstream,omitempty"`
+		Stack       []string             `json:"stack,omitempty"`
+		Causes      map[string]jsonError `json:"causes,omitempty"`
+		Errors      []jsonError          `json:"errors,omitempty"`
+		Attachments []Attachment         `json:"attachments,omitempty"`
+
+		// extra holds the fields unknown to this version of the package, preserved from a parsed envelope.
+		extra map[string]json.RawMessage
+	}
+)
+
+// MarshalJSON serializes the error with its message, description, call stack and named causes.
+//
+// Returns:
+//   - []byte: the JSON encoding of the error
+//   - error: an error if the encoding fails
+func (e *Error) MarshalJSON() ([]byte, error) {
+	out := toJSONError(e)
+	out.Version = WireVersion
+
+	return json.Marshal(out)
+}
+
+func toJSONError(err error) jsonError {
+	out := jsonError{Message: ScrubMessage(err.Error())}
+
+	if aggregate, ok := err.(*Aggregate); ok { //nolint:errorlint
+		out.Errors = make([]jsonError, 0, aggregate.Len())
+		for _, member := range aggregate.errs {
+			out.Errors = append(out.Errors, toJSONError(member))
+		}
+
+		return out
+	}
+
+	frameworkErr, isFrameworkErr := err.(*Error) //nolint:errorlint
+	if isFrameworkErr {
+		out.Description = ScrubMessage(frameworkErr.Description)
+	}
+
+	out.Code = AutoCode(err)
+	out.Fingerprint = Normalize(err).Fingerprint()
+	out.InstanceID = InstanceID(err)
+	out.Template = TemplateOf(err)
+	out.Fields = ScrubFields(FieldsOf(err))
+	out.Tags = TagsOf(err)
+	out.extra = wireExtra(err)
+
+	if upstream, ok := UpstreamOf(err); ok {
+		out.Upstream = &upstream
+	}
+
+	if origin := FindOriginalErrorWithStack(err); origin != nil {
+		out.Stack = origin.GetCallStack()
+	}
+
+	if isFrameworkErr && frameworkErr.wire != nil {
+		// A parsed error re-emits what it was received with, since it has no local origin.
+		out.Description = frameworkErr.wire.description
+		out.Code = frameworkErr.wire.code
+		out.Fingerprint = frameworkErr.wire.fingerprint
+		out.Stack = frameworkErr.wire.stack
+	}
+
+	for _, attachment := range AttachmentsOf(err) {
+		out.Attachments = append(out.Attachments, ScrubAttachment(attachment))
+	}
+
+	if causes := NamedCauses(err); len(causes) > 0 {
+		out.Causes = make(map[string]jsonError, len(causes))
+		for name, cause := range causes {
+			out.Causes[name] = toJSONError(cause)
+		}
+	}
+
+	return out
+}
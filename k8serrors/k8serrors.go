@@ -0,0 +1,102 @@
+// Package k8serrors translates Kubernetes API server errors (k8s.io/apimachinery StatusError)
+// into the predefined error taxonomy and back, so controllers and operators classify API failures consistently.
+package k8serrors
+
+import (
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ceearrashee/errors"
+)
+
+//nolint:gochecknoglobals
+var (
+	reasonSentinels = map[metav1.StatusReason]error{
+		metav1.StatusReasonBadRequest:      errors.ErrBadRequest,
+		metav1.StatusReasonUnauthorized:    errors.ErrUnauthorized,
+		metav1.StatusReasonForbidden:       errors.ErrForbiddenAction,
+		metav1.StatusReasonNotFound:        errors.ErrNotFound,
+		metav1.StatusReasonAlreadyExists:   errors.ErrConflict,
+		metav1.StatusReasonConflict:        errors.ErrConflict,
+		metav1.StatusReasonInvalid:         errors.ErrValidation,
+		metav1.StatusReasonTooManyRequests: errors.ErrTooManyRequests,
+		metav1.StatusReasonInternalError:   errors.ErrInternalServerError,
+		metav1.StatusReasonTimeout:         errors.ErrTimeout,
+		metav1.StatusReasonServerTimeout:   errors.ErrTimeout,
+	}
+
+	sentinelReasons = []struct {
+		sentinel error
+		reason   metav1.StatusReason
+		code     int32
+	}{
+		{errors.ErrBadRequest, metav1.StatusReasonBadRequest, http.StatusBadRequest},
+		{errors.ErrUnauthorized, metav1.StatusReasonUnauthorized, http.StatusUnauthorized},
+		{errors.ErrRegistrationRequired, metav1.StatusReasonUnauthorized, http.StatusUnauthorized},
+		{errors.ErrForbiddenAction, metav1.StatusReasonForbidden, http.StatusForbidden},
+		{errors.ErrNotFound, metav1.StatusReasonNotFound, http.StatusNotFound},
+		{errors.ErrConflict, metav1.StatusReasonConflict, http.StatusConflict},
+		{errors.ErrValidation, metav1.StatusReasonInvalid, http.StatusUnprocessableEntity},
+		{errors.ErrTooManyRequests, metav1.StatusReasonTooManyRequests, http.StatusTooManyRequests},
+		{errors.ErrTimeout, metav1.StatusReasonTimeout, http.StatusGatewayTimeout},
+	}
+)
+
+// FromStatusError classifies a Kubernetes API error by its status reason, e.g. NotFound becomes ErrNotFound
+// and Invalid becomes ErrValidation. The original StatusError stays in the chain.
+//
+// Parameters:
+//   - err: the error returned by a Kubernetes client call
+//
+// Returns:
+//   - error: the classified error with a call stack, err unchanged if it carries no recognized reason, or nil if err is nil
+func FromStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	sentinel, ok := reasonSentinels[apierrors.ReasonForError(err)]
+	if !ok {
+		return err
+	}
+
+	return errors.WrapSkipping(fmt.Errorf("%w: %w", sentinel, err), 1, "")
+}
+
+// ToStatusError converts an error into a Kubernetes StatusError whose reason and code reflect the
+// predefined error found in the chain; unclassified errors become InternalError.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - *apierrors.StatusError: the equivalent status error, the existing one if the chain already contains one, or nil if err is nil
+func ToStatusError(err error) *apierrors.StatusError {
+	if err == nil {
+		return nil
+	}
+
+	if statusErr, ok := errors.AsType[*apierrors.StatusError](err); ok {
+		return statusErr
+	}
+
+	reason, code := metav1.StatusReasonInternalError, int32(http.StatusInternalServerError)
+
+	for _, mapping := range sentinelReasons {
+		if errors.Is(err, mapping.sentinel) {
+			reason, code = mapping.reason, mapping.code
+
+			break
+		}
+	}
+
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Message: err.Error(),
+	}}
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// maxKnownSites caps the number of creation-site fingerprints remembered by the known-site registry.
+const maxKnownSites = 10000
+
+//nolint:gochecknoglobals
+var (
+	knownSitesMu sync.RWMutex
+	knownSites   = make(map[string]struct{})
+)
+
+// IsKnownSite reports whether an error with the same creation-site fingerprint (see AutoCode) was observed before,
+// either since the process started or in the sites loaded with LoadKnownSites. Reporters call it before Observe,
+// which remembers the site, to tag brand-new error sites so on-call can prioritize novel failures during deploys.
+//
+// Parameters:
+//   - err: the error to check
+//
+// Returns:
+//   - bool: true if the site was seen before, false if it is new or err is nil
+func IsKnownSite(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	site := AutoCode(err)
+
+	knownSitesMu.RLock()
+	defer knownSitesMu.RUnlock()
+
+	_, known := knownSites[site]
+
+	return known
+}
+
+// KnownSites returns the creation-site fingerprints remembered so far, sorted.
+//
+// Returns:
+//   - []string: the known sites
+func KnownSites() []string {
+	knownSitesMu.RLock()
+	sites := make([]string, 0, len(knownSites))
+
+	for site := range knownSites {
+		sites = append(sites, site)
+	}
+	knownSitesMu.RUnlock()
+
+	slices.Sort(sites)
+
+	return sites
+}
+
+// SaveKnownSites writes the known sites, one per line, so they can be persisted across deploys.
+//
+// Parameters:
+//   - w: the writer to write to
+//
+// Returns:
+//   - error: an error if writing fails
+func SaveKnownSites(w io.Writer) error {
+	for _, site := range KnownSites() {
+		if _, err := io.WriteString(w, site+"\n"); err != nil {
+			return Wrap(err, "save known sites")
+		}
+	}
+
+	return nil
+}
+
+// LoadKnownSites adds the sites written by SaveKnownSites to the known sites.
+//
+// Parameters:
+//   - r: the reader to read from
+//
+// Returns:
+//   - error: an error if reading fails
+func LoadKnownSites(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if site := strings.TrimSpace(scanner.Text()); site != "" {
+			rememberSite(site)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Wrap(err, "load known sites")
+	}
+
+	return nil
+}
+
+// ResetKnownSites forgets every known site.
+func ResetKnownSites() {
+	knownSitesMu.Lock()
+	defer knownSitesMu.Unlock()
+
+	clear(knownSites)
+}
+
+func rememberSite(site string) {
+	knownSitesMu.Lock()
+	defer knownSitesMu.Unlock()
+
+	if _, known := knownSites[site]; known || len(knownSites) >= maxKnownSites {
+		return
+	}
+
+	knownSites[site] = struct{}{}
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"sync"
+)
+
+type (
+	// lazyMessage is an error whose message is computed on first use.
+	lazyMessage struct {
+		once    sync.Once
+		compute func() string
+		message string
+	}
+)
+
+// NewLazy creates an error with a call stack whose description is computed by describe only when the error
+// is formatted, serialized or reported, for expensive descriptions (large diffs, serialized state) on paths
+// where errors are usually swallowed or matched with Is and As. describe runs at most once.
+//
+// Parameters:
+//   - describe: the function computing the description
+//
+// Returns:
+//   - error: the newly created error
+func NewLazy(describe func() string) error {
+	if describe == nil {
+		reportMisuse("NewLazy called with a nil description function")
+
+		describe = func() string { return "" }
+	}
+
+	return track(&Error{
+		stack: callers(),
+		error: &lazyMessage{compute: describe},
+	})
+}
+
+// Error computes the message on first use.
+func (l *lazyMessage) Error() string {
+	l.once.Do(func() {
+		l.message = limitDescription(l.compute())
+		l.compute = nil
+	})
+
+	return l.message
+}
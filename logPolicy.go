@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// LogPolicy maps errors to the level they should be logged at, so expected client errors
+// (not found, validation...) stop being logged at ERROR and triggering alerts. Logging adapters,
+// middlewares and reporters consult it through LogLevelOf.
+type LogPolicy struct {
+	// Levels maps sentinel errors to their level; the outermost level of the chain that is a sentinel, or claims to
+	// be one through its Is method, wins.
+	Levels map[error]slog.Level
+	// Codes maps error codes to their level; it is consulted when no sentinel of Levels matches.
+	Codes map[ErrorCode]slog.Level
+	// Default is the level of errors matching no rule.
+	Default slog.Level
+}
+
+var (
+	logPolicy        atomic.Pointer[LogPolicy] //nolint:gochecknoglobals
+	defaultLogPolicy = DefaultLogPolicy()      //nolint:gochecknoglobals
+)
+
+// DefaultLogPolicy returns the policy used until SetLogPolicy is called: predefined client errors
+// (4xx) are logged at WARN, everything else at ERROR.
+//
+// Returns:
+//   - LogPolicy: the default policy
+func DefaultLogPolicy() LogPolicy {
+	return LogPolicy{
+		Levels: map[error]slog.Level{
+			ErrBadRequest:           slog.LevelWarn,
+			ErrUnauthorized:         slog.LevelWarn,
+			ErrRegistrationRequired: slog.LevelWarn,
+			ErrPaymentError:         slog.LevelWarn,
+			ErrForbiddenAction:      slog.LevelWarn,
+			ErrNotFound:             slog.LevelWarn,
+			ErrConflict:             slog.LevelWarn,
+			ErrPreconditionFailed:   slog.LevelWarn,
+			ErrValidation:           slog.LevelWarn,
+			ErrTooManyRequests:      slog.LevelWarn,
+			ErrCanceled:             slog.LevelWarn,
+		},
+		Default: slog.LevelError,
+	}
+}
+
+// SetLogPolicy replaces the package-level log policy consulted by LogLevelOf.
+//
+// Parameters:
+//   - policy: the policy to apply
+func SetLogPolicy(policy LogPolicy) {
+	logPolicy.Store(&policy)
+}
+
+// LogLevelOf returns the level err should be logged at according to the package-level log policy.
+//
+// Parameters:
+//   - err: the error to log
+//
+// Returns:
+//   - slog.Level: the level to log the error at
+func LogLevelOf(err error) slog.Level {
+	if policy := logPolicy.Load(); policy != nil {
+		return policy.LevelOf(err)
+	}
+
+	return defaultLogPolicy.LevelOf(err)
+}
+
+// LevelOf returns the level err should be logged at according to the policy.
+//
+// Parameters:
+//   - err: the error to log
+//
+// Returns:
+//   - slog.Level: slog.LevelWarn for errors holding only warnings (see OnlyWarnings), else the level of the outermost
+//     matching sentinel, else of the error code, else the default level
+func (p LogPolicy) LevelOf(err error) slog.Level {
+	if err == nil {
+		return p.Default
+	}
+
+	if OnlyWarnings(err) {
+		return slog.LevelWarn
+	}
+
+	if level, ok := sentinelLookup(err, p.Levels); ok {
+		return level
+	}
+
+	if level, ok := p.Codes[CodeOf(err)]; ok {
+		return level
+	}
+
+	return p.Default
+}
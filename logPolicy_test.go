@@ -0,0 +1,37 @@
+package errors_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestLogPolicyMatchesSentinels(t *testing.T) {
+	t.Parallel()
+
+	policy := errors.LogPolicy{
+		Levels:  map[error]slog.Level{errors.ErrNotFound: slog.LevelInfo, errQuota: slog.LevelWarn},
+		Default: slog.LevelError,
+	}
+
+	cases := map[string]struct {
+		err  error
+		want slog.Level
+	}{
+		"annotated sentinel": {errors.Annotate(errors.ErrNotFound, errors.WithField("k", 1)), slog.LevelInfo},
+		"wrapped sentinel":   {errors.Wrap(errors.ErrNotFound, "load user"), slog.LevelInfo},
+		"custom Is":          {errors.Wrap(quotaError{tenant: "acme"}, "charge"), slog.LevelWarn},
+		"unmatched":          {errors.New("boom"), slog.LevelError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := policy.LevelOf(tc.err); got != tc.want {
+				t.Fatalf("LevelOf = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
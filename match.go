@@ -0,0 +1,179 @@
+package errors
+
+import "reflect"
+
+type (
+	// Matcher dispatches an error to the handler of the first matching case, replacing if/else-if ladders of Is and As:
+	//
+	//	errors.Match(err).
+	//		Case(errors.ErrNotFound, func(error) { w.WriteHeader(http.StatusNotFound) }).
+	//		CaseCode(CodeQuota, func(error) { w.WriteHeader(http.StatusTooManyRequests) }).
+	//		CaseType(errors.Typed(func(v *ValidationError) { writeViolations(w, v) })).
+	//		Default(func(err error) { w.WriteHeader(http.StatusInternalServerError) })
+	//
+	// The chain is walked once, when the matcher is created. Cases are tried in order and only the handler of
+	// the first matching one runs; a nil error matches no case and does not run the default handler.
+	Matcher struct {
+		err     error
+		chain   []error
+		code    *ErrorCode
+		matched bool
+	}
+
+	// TypeCase is a case of a Matcher matching an error type, created by Typed.
+	TypeCase struct {
+		handle func(chain []error) bool
+	}
+)
+
+// Match returns a matcher dispatching err to the first matching case.
+//
+// Parameters:
+//   - err: the error to dispatch
+//
+// Returns:
+//   - *Matcher: the matcher
+func Match(err error) *Matcher {
+	m := &Matcher{err: err, matched: err == nil}
+
+	for e := range Chain(err) {
+		m.chain = append(m.chain, e)
+	}
+
+	return m
+}
+
+// Typed returns a case matching the first error of the chain of type T, like As, passing it to fn.
+//
+// Parameters:
+//   - fn: the handler receiving the matching error
+//
+// Returns:
+//   - TypeCase: the case to pass to Matcher.CaseType
+func Typed[T error](fn func(T)) TypeCase {
+	return TypeCase{handle: func(chain []error) bool {
+		for _, e := range chain {
+			if target, ok := e.(T); ok { //nolint:errorlint
+				fn(target)
+
+				return true
+			}
+
+			if custom, ok := e.(interface{ As(target any) bool }); ok {
+				var target T
+				if custom.As(&target) {
+					fn(target)
+
+					return true
+				}
+			}
+		}
+
+		return false
+	}}
+}
+
+// Case runs fn if the chain matches target, like Is.
+//
+// Parameters:
+//   - target: the error to match
+//   - fn: the handler receiving the matched error
+//
+// Returns:
+//   - *Matcher: the matcher, for chaining
+func (m *Matcher) Case(target error, fn func(error)) *Matcher {
+	return m.CaseFunc(func(error) bool { return m.contains(target) }, fn)
+}
+
+// CaseCode runs fn if the code of the error (see CodeOf) is code.
+//
+// Parameters:
+//   - code: the code to match
+//   - fn: the handler receiving the matched error
+//
+// Returns:
+//   - *Matcher: the matcher, for chaining
+func (m *Matcher) CaseCode(code ErrorCode, fn func(error)) *Matcher {
+	return m.CaseFunc(func(err error) bool {
+		if m.code == nil {
+			errorCode := CodeOf(err)
+			m.code = &errorCode
+		}
+
+		return *m.code == code
+	}, fn)
+}
+
+// CaseType runs the handler of the type case if the chain holds an error of its type.
+//
+// Parameters:
+//   - c: the case created by Typed
+//
+// Returns:
+//   - *Matcher: the matcher, for chaining
+func (m *Matcher) CaseType(c TypeCase) *Matcher {
+	if !m.matched && c.handle != nil {
+		m.matched = c.handle(m.chain)
+	}
+
+	return m
+}
+
+// CaseFunc runs fn if match reports true for the error.
+//
+// Parameters:
+//   - match: the predicate receiving the error
+//   - fn: the handler receiving the matched error
+//
+// Returns:
+//   - *Matcher: the matcher, for chaining
+func (m *Matcher) CaseFunc(match func(error) bool, fn func(error)) *Matcher {
+	if !m.matched && match(m.err) {
+		m.matched = true
+
+		fn(m.err)
+	}
+
+	return m
+}
+
+// Default runs fn if no case matched a non-nil error.
+//
+// Parameters:
+//   - fn: the handler receiving the error
+func (m *Matcher) Default(fn func(error)) {
+	if !m.matched {
+		m.matched = true
+
+		fn(m.err)
+	}
+}
+
+// Matched reports whether a case matched, or the error is nil.
+//
+// Returns:
+//   - bool: true if a handler ran or the error is nil
+func (m *Matcher) Matched() bool {
+	return m.matched
+}
+
+// contains reports whether an error of the chain is target or claims to match it, like Is.
+func (m *Matcher) contains(target error) bool {
+	if target == nil {
+		return false
+	}
+
+	canCompare := reflect.TypeOf(target).Comparable()
+
+	for _, e := range m.chain {
+		if canCompare && e == target { //nolint:errorlint
+			return true
+		}
+
+		if custom, ok := e.(interface{ Is(target error) bool }); ok && custom.Is(target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// FieldArgPrefix prefixes the fields holding interpolated arguments moved out of the message, e.g. "arg.1".
+	FieldArgPrefix = "arg."
+	// maxTrackedFormats caps the number of format strings whose argument cardinality is tracked.
+	maxTrackedFormats = 1000
+	// truncationSuffix terminates descriptions cut short by MessageLimits.MaxLength.
+	truncationSuffix = "…[truncated]"
+)
+
+type (
+	// MessageLimits caps the size and cardinality of error descriptions, protecting log indices and span tag limits
+	// from huge messages embedding entire payloads. Zero values disable the corresponding limit; all are disabled by default.
+	MessageLimits struct {
+		// MaxLength is the maximum length in bytes of a description; longer descriptions are truncated.
+		MaxLength int
+		// MaxArgLength is the maximum length in bytes of a formatted argument of Newf, Wrapf and similar constructors.
+		// Longer arguments are replaced in the description by a short hash and moved into an "arg.<position>" field,
+		// itself truncated to MaxLength.
+		MaxArgLength int
+		// MaxArgCardinality is the maximum number of distinct values an argument may take for a given format string.
+		// Beyond it, values at that position are hashed into fields as well, keeping descriptions groupable.
+		MaxArgCardinality int
+	}
+
+	// hashedArg replaces an argument moved out of the description, whatever the formatting verb.
+	hashedArg string
+
+	formatCardinality struct {
+		mu     sync.Mutex
+		values map[string]map[int]map[string]struct{}
+	}
+)
+
+//nolint:gochecknoglobals
+var (
+	messageLimits atomic.Pointer[MessageLimits]
+	cardinality   = &formatCardinality{values: make(map[string]map[int]map[string]struct{})}
+)
+
+// SetMessageLimits replaces the package-level limits applied to error descriptions.
+//
+// Parameters:
+//   - limits: the limits to apply
+func SetMessageLimits(limits MessageLimits) {
+	messageLimits.Store(&limits)
+}
+
+// Format implements fmt.Formatter.
+func (h hashedArg) Format(f fmt.State, _ rune) {
+	_, _ = fmt.Fprintf(f, "[#%s]", string(h)) //nolint:errcheck,revive
+}
+
+// formatDescription formats a description like fmt.Sprintf, applying the package-level message limits.
+// Arguments moved out of the description are returned as fields, or nil when none were moved.
+func formatDescription(format string, args []any) (string, Fields) {
+	limits := messageLimits.Load()
+	if limits == nil {
+		return fmt.Sprintf(format, args...), nil
+	}
+
+	var fields Fields
+
+	if limits.MaxArgLength > 0 || limits.MaxArgCardinality > 0 {
+		var replaced []any
+
+		for i, arg := range args {
+			if _, redacted := arg.(SecretValue); redacted {
+				continue
+			}
+
+			value := fmt.Sprint(arg)
+			tooLong := limits.MaxArgLength > 0 && len(value) > limits.MaxArgLength
+
+			if !tooLong && !cardinality.exceeded(format, i, value, limits.MaxArgCardinality) {
+				continue
+			}
+
+			if replaced == nil {
+				replaced = append([]any(nil), args...)
+				fields = make(Fields)
+			}
+
+			replaced[i] = hashedArg(hashValue(value))
+			fields[FieldArgPrefix+strconv.Itoa(i)] = limitLength(value, limits.MaxLength)
+		}
+
+		if replaced != nil {
+			args = replaced
+		}
+	}
+
+	return limitLength(fmt.Sprintf(format, args...), limits.MaxLength), fields
+}
+
+// limitDescription applies the package-level length limit to a description.
+func limitDescription(description string) string {
+	if limits := messageLimits.Load(); limits != nil {
+		return limitLength(description, limits.MaxLength)
+	}
+
+	return description
+}
+
+// exceeded records value for the argument at position of format and reports whether the position
+// took more than limit distinct values.
+func (c *formatCardinality) exceeded(format string, position int, value string, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	positions, tracked := c.values[format]
+	if !tracked {
+		if len(c.values) >= maxTrackedFormats {
+			return false
+		}
+
+		positions = make(map[int]map[string]struct{})
+		c.values[format] = positions
+	}
+
+	values := positions[position]
+	if values == nil {
+		values = make(map[string]struct{})
+		positions[position] = values
+	}
+
+	if _, seen := values[value]; seen {
+		return len(values) > limit
+	}
+
+	// Stop growing once over the limit: the position is hashed from now on anyway.
+	if len(values) > limit {
+		return true
+	}
+
+	values[value] = struct{}{}
+
+	return len(values) > limit
+}
+
+func limitLength(s string, maxLength int) string {
+	if maxLength <= 0 || len(s) <= maxLength {
+		return s
+	}
+
+	return truncateUTF8(s, maxLength) + truncationSuffix
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncateUTF8(s string, n int) string {
+	for n > 0 && n < len(s) && s[n]&0xC0 == 0x80 {
+		n--
+	}
+
+	return s[:n]
+}
+
+func hashValue(value string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(value)) //nolint:errcheck,revive
+
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
@@ -0,0 +1,76 @@
+package errors
+
+// WithNamedCause attaches a secondary cause under the given name, in addition to the primary wrapped error.
+// Secondary causes do not take part in Is/As matching, but they are serialized and reported alongside the error.
+//
+// Parameters:
+//   - err: the error to annotate; if nil, nil is returned
+//   - name: the label of the secondary cause (e.g., "rollback_error")
+//   - cause: the secondary cause; if nil, err is returned unchanged
+//
+// Returns:
+//   - error: the error carrying the named cause
+func WithNamedCause(err error, name string, cause error) error {
+	if cause == nil {
+		return err
+	}
+
+	return annotate(err, func(e *Error) {
+		if e.causes == nil {
+			e.causes = make(map[string]error, 1)
+		}
+
+		e.causes[name] = cause
+	})
+}
+
+// NamedCause returns the secondary cause attached under the given name anywhere in the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//   - name: the label of the secondary cause
+//
+// Returns:
+//   - error: the outermost cause registered under name, or nil if none is found
+func NamedCause(err error, name string) error {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok { //nolint:errorlint
+			if cause, found := frameworkErr.causes[name]; found {
+				return cause
+			}
+		}
+	}
+
+	return nil
+}
+
+// NamedCauses collects all secondary causes attached anywhere in the chain.
+// When the same name is used at several levels, the outermost cause wins.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - map[string]error: the named causes keyed by label, or nil if there are none
+func NamedCauses(err error) map[string]error {
+	var causes map[string]error
+
+	for e := range Chain(err) {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
+		if !ok {
+			continue
+		}
+
+		for name, cause := range frameworkErr.causes {
+			if causes == nil {
+				causes = make(map[string]error)
+			}
+
+			if _, exists := causes[name]; !exists {
+				causes[name] = cause
+			}
+		}
+	}
+
+	return causes
+}
@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type (
+	// NilWrap counts the calls of a wrapping function with a nil error from a single call site, recorded while
+	// debug mode is enabled. Such calls silently return nil, which hides logic bugs where the caller thought
+	// an error existed, e.g. wrapping the wrong variable.
+	NilWrap struct {
+		Function    string    `json:"function,omitempty"`
+		File        string    `json:"file,omitempty"`
+		Line        int       `json:"line,omitempty"`
+		Call        string    `json:"call"`
+		Description string    `json:"description,omitempty"`
+		Count       int       `json:"count"`
+		Last        time.Time `json:"last"`
+	}
+)
+
+// NilWraps returns the call sites that called Wrap, Wrapf, WrapCtxf or another wrapping function with a nil error
+// since debug mode was enabled (see EnableDebug), most frequent first.
+//
+// Returns:
+//   - []NilWrap: the recorded call sites, or nil if debug mode is disabled
+func NilWraps() []NilWrap {
+	ring := debugLog.Load()
+	if ring == nil {
+		return nil
+	}
+
+	ring.mu.Lock()
+	sites := make([]NilWrap, 0, len(ring.nilWraps))
+
+	for _, site := range ring.nilWraps {
+		sites = append(sites, *site)
+	}
+	ring.mu.Unlock()
+
+	slices.SortFunc(sites, func(a, b NilWrap) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+	})
+
+	return sites
+}
+
+// recordNilWrap counts a call of a wrapping function with a nil error when debug mode is enabled. It must be called
+// directly by the wrapping function, so the recorded site is its caller, additionally skipping the given frames.
+func recordNilWrap(call, description string, skip int) {
+	ring := debugLog.Load()
+	if ring == nil {
+		return
+	}
+
+	var pcs [8]uintptr
+
+	if runtime.Callers(3+skip, pcs[:]) == 0 { //nolint:mnd
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	key := frame.File + ":" + strconv.Itoa(frame.Line)
+
+	ring.mu.Lock()
+	defer ring.mu.Unlock()
+
+	site, ok := ring.nilWraps[key]
+	if !ok {
+		site = &NilWrap{Function: frame.Function, File: frame.File, Line: frame.Line, Call: call}
+		ring.nilWraps[key] = site
+	}
+
+	site.Description = description
+	site.Count++
+	site.Last = now()
+}
@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// NormalizedError is the canonical form of an error: its message template with interpolated values
+	// extracted into fields, its codes, and its fields and tags in a stable order. Two occurrences of the
+	// same failure normalize identically regardless of the values involved, across services and versions.
+	NormalizedError struct {
+		// Template is the message template; explicit templates are kept, other messages have their values replaced by {argN}.
+		Template string `json:"template"`
+		// Code is the catalogue code of the error, if classified.
+		Code ErrorCode `json:"code,omitempty"`
+		// ReferenceCode is the AutoCode of the error.
+		ReferenceCode string `json:"reference_code,omitempty"`
+		// Fields holds the structured fields and the extracted values, sorted by key.
+		Fields []NormalizedField `json:"fields,omitempty"`
+		// Tags holds the tags of the error, sorted.
+		Tags []string `json:"tags,omitempty"`
+	}
+
+	// NormalizedField is a single field of a NormalizedError with its value rendered as a string.
+	NormalizedField struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+)
+
+// valuePattern matches the variable parts of free-form messages: UUIDs, quoted strings, hex and decimal numbers.
+var valuePattern = regexp.MustCompile( //nolint:gochecknoglobals
+	`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|"[^"]*"|'[^']*'|\b0x[0-9a-fA-F]+\b|\b\d+(?:\.\d+)?\b`,
+)
+
+// Normalize produces the canonical form of err, used for deduplication, fingerprinting and comparing
+// errors across services.
+//
+// Parameters:
+//   - err: the error to normalize
+//
+// Returns:
+//   - NormalizedError: the canonical form, or the zero value if err is nil
+func Normalize(err error) NormalizedError {
+	if err == nil {
+		return NormalizedError{}
+	}
+
+	normalized := NormalizedError{
+		Code:          CodeOf(err),
+		ReferenceCode: AutoCode(err),
+	}
+
+	fields := FieldsOf(err)
+
+	if normalized.Template = TemplateOf(err); normalized.Template == "" {
+		var args []string
+
+		normalized.Template, args = extractValues(stableMessage(err))
+
+		for i, arg := range args {
+			if fields == nil {
+				fields = make(Fields, len(args))
+			}
+
+			fields["arg"+strconv.Itoa(i)] = arg
+		}
+	}
+
+	for key, value := range fields {
+		normalized.Fields = append(normalized.Fields, NormalizedField{Key: key, Value: fmt.Sprint(value)})
+	}
+
+	slices.SortFunc(normalized.Fields, func(a, b NormalizedField) int { return strings.Compare(a.Key, b.Key) })
+
+	if tags := TagsOf(err); len(tags) > 0 {
+		normalized.Tags = slices.Sorted(slices.Values(tags))
+	}
+
+	return normalized
+}
+
+// Fingerprint returns a hash of the template and code, identical for all occurrences of the same failure.
+//
+// Returns:
+//   - string: the hexadecimal fingerprint
+func (n NormalizedError) Fingerprint() string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(n.Template + "\x00" + string(n.Code))) //nolint:errcheck,revive
+
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// extractValues replaces the variable parts of a message with {argN} placeholders.
+func extractValues(message string) (string, []string) {
+	var args []string
+
+	template := valuePattern.ReplaceAllStringFunc(message, func(value string) string {
+		args = append(args, strings.Trim(value, `"'`))
+
+		return "{arg" + strconv.Itoa(len(args)-1) + "}"
+	})
+
+	return template, args
+}
@@ -0,0 +1,149 @@
+// Package openapigen emits OpenAPI 3.1 response components describing the registered error catalogue,
+// so API documentation stays in sync with the errors a service can actually return.
+package openapigen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// ProblemContentType is the media type of RFC 9457 problem details responses.
+	ProblemContentType = "application/problem+json"
+	// ProblemSchemaName is the name of the shared problem details schema component.
+	ProblemSchemaName = "Problem"
+)
+
+type (
+	// Document is a minimal OpenAPI 3.1 document carrying only the generated components.
+	Document struct {
+		OpenAPI    string     `json:"openapi"`
+		Components Components `json:"components"`
+	}
+
+	// Components holds the generated schemas and responses.
+	Components struct {
+		Schemas   map[string]Schema   `json:"schemas"`
+		Responses map[string]Response `json:"responses"`
+	}
+
+	// Response is an OpenAPI response object.
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content"`
+	}
+
+	// MediaType is an OpenAPI media type object.
+	MediaType struct {
+		Schema Schema `json:"schema"`
+	}
+
+	// Schema is the subset of JSON Schema used by the generated components.
+	Schema struct {
+		Ref         string            `json:"$ref,omitempty"`
+		Type        string            `json:"type,omitempty"`
+		Format      string            `json:"format,omitempty"`
+		Description string            `json:"description,omitempty"`
+		Enum        []string          `json:"enum,omitempty"`
+		Const       any               `json:"const,omitempty"`
+		Required    []string          `json:"required,omitempty"`
+		Properties  map[string]Schema `json:"properties,omitempty"`
+		AllOf       []Schema          `json:"allOf,omitempty"`
+	}
+)
+
+// Generate builds the OpenAPI components for the given error definitions.
+// One response component named "Error<status>" is emitted per HTTP status, whose schema extends the
+// shared problem details schema with the enumeration of codes returned with that status.
+//
+// Parameters:
+//   - defs: the error definitions to describe, typically errors.Definitions()
+//
+// Returns:
+//   - Document: the generated OpenAPI document
+func Generate(defs []errors.ErrorDefinition) Document {
+	codesByStatus := make(map[int][]string)
+
+	for _, def := range defs {
+		status := def.HTTPStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+
+		codesByStatus[status] = append(codesByStatus[status], string(def.Code))
+	}
+
+	responses := make(map[string]Response, len(codesByStatus))
+
+	for status, codes := range codesByStatus {
+		slices.Sort(codes)
+
+		responses["Error"+strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content: map[string]MediaType{
+				ProblemContentType: {
+					Schema: Schema{
+						AllOf: []Schema{
+							{Ref: "#/components/schemas/" + ProblemSchemaName},
+							{
+								Type: "object",
+								Properties: map[string]Schema{
+									"status": {Const: status},
+									"code":   {Type: "string", Enum: codes},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return Document{
+		OpenAPI: "3.1.0",
+		Components: Components{
+			Schemas:   map[string]Schema{ProblemSchemaName: problemSchema()},
+			Responses: responses,
+		},
+	}
+}
+
+// Write generates the components for the registered error catalogue and writes them as indented JSON.
+//
+// Parameters:
+//   - w: the destination writer
+//
+// Returns:
+//   - error: an error if encoding or writing fails
+func Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(Generate(errors.Definitions())); err != nil {
+		return errors.Wrap(err, "failed to encode OpenAPI components")
+	}
+
+	return nil
+}
+
+func problemSchema() Schema {
+	return Schema{
+		Type:        "object",
+		Description: fmt.Sprintf("Problem details (RFC 9457) returned as %s.", ProblemContentType),
+		Required:    []string{"status", "code"},
+		Properties: map[string]Schema{
+			"type":     {Type: "string", Format: "uri-reference"},
+			"title":    {Type: "string"},
+			"status":   {Type: "integer"},
+			"detail":   {Type: "string"},
+			"instance": {Type: "string", Format: "uri-reference", Description: "Instance ID of the error occurrence."},
+			"code":     {Type: "string", Description: "Stable machine-readable error code."},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"maps"
+)
+
+type (
+	// Option sets metadata on an Error, such as tags or attachments, without altering its message.
+	Option func(*Error)
+)
+
+// Annotate applies the options to err. When err is an *Error wrapping another error it is copied, leaving the
+// original untouched; sentinels and other errors are wrapped into a description-less *Error carrying the
+// metadata, so Is(Annotate(ErrNotFound, ...), ErrNotFound) still holds.
+//
+// Parameters:
+//   - err: the error to annotate; if nil, nil is returned
+//   - opts: the options to apply
+//
+// Returns:
+//   - error: the annotated error
+func Annotate(err error, opts ...Option) error {
+	return annotate(err, func(e *Error) {
+		for _, opt := range opts {
+			opt(e)
+		}
+	})
+}
+
+// WithCode sets an explicit code on the error, taking precedence over the code of a matching definition.
+//
+// Parameters:
+//   - code: the code to set
+//
+// Returns:
+//   - Option: the option setting the code
+func WithCode(code ErrorCode) Option {
+	return func(e *Error) {
+		e.code = code
+	}
+}
+
+// WithField sets a single structured field on the error.
+//
+// Parameters:
+//   - key: the field name
+//   - value: the field value
+//
+// Returns:
+//   - Option: the option setting the field
+func WithField(key string, value any) Option {
+	return func(e *Error) {
+		if e.fields == nil {
+			e.fields = make(Fields, 1)
+		}
+
+		e.fields[key] = value
+	}
+}
+
+// WithFields sets structured fields on the error, overriding existing fields with the same keys.
+//
+// Parameters:
+//   - fields: the fields to set
+//
+// Returns:
+//   - Option: the option setting the fields
+func WithFields(fields Fields) Option {
+	return func(e *Error) {
+		if e.fields == nil {
+			e.fields = make(Fields, len(fields))
+		}
+
+		maps.Copy(e.fields, fields)
+	}
+}
+
+// With returns a copy of the error with the options applied, leaving the receiver untouched. Sentinels are
+// wrapped rather than copied, like Annotate does.
+//
+// Parameters:
+//   - opts: the options to apply
+//
+// Returns:
+//   - *Error: the annotated copy, or nil if the receiver is nil
+func (e *Error) With(opts ...Option) *Error {
+	if e == nil {
+		return nil
+	}
+
+	annotated := e.annotated()
+	for _, opt := range opts {
+		opt(annotated)
+	}
+
+	return annotated
+}
+
+// WithCode returns a copy of the error with an explicit code.
+//
+// Parameters:
+//   - code: the code to set
+//
+// Returns:
+//   - *Error: the annotated copy, or nil if the receiver is nil
+func (e *Error) WithCode(code ErrorCode) *Error {
+	return e.With(WithCode(code))
+}
+
+// WithField returns a copy of the error with a structured field set.
+//
+// Parameters:
+//   - key: the field name
+//   - value: the field value
+//
+// Returns:
+//   - *Error: the annotated copy, or nil if the receiver is nil
+func (e *Error) WithField(key string, value any) *Error {
+	return e.With(WithField(key, value))
+}
+
+// WithFields returns a copy of the error with structured fields set.
+//
+// Parameters:
+//   - fields: the fields to set
+//
+// Returns:
+//   - *Error: the annotated copy, or nil if the receiver is nil
+func (e *Error) WithFields(fields Fields) *Error {
+	return e.With(WithFields(fields))
+}
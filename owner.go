@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const packagePath = "github.com/ceearrashee/errors"
+
+var (
+	ownersMu sync.RWMutex      //nolint:gochecknoglobals
+	owners   map[string]string //nolint:gochecknoglobals
+)
+
+// RegisterOwner declares the team owning the code under a path prefix, in the spirit of CODEOWNERS.
+// The prefix is matched against both the source file path and the function's package path of stack frames,
+// so "github.com/acme/shop/billing" and "/src/shop/billing/" are both valid prefixes. The longest prefix wins.
+//
+// Parameters:
+//   - pathPrefix: the file path or package path prefix
+//   - team: the owning team, e.g. "payments"
+func RegisterOwner(pathPrefix, team string) {
+	ownersMu.Lock()
+	defer ownersMu.Unlock()
+
+	if owners == nil {
+		owners = make(map[string]string)
+	}
+
+	owners[pathPrefix] = team
+}
+
+// OwnerOf returns the owner set explicitly with WithOwner, or else resolves the team owning the error from its
+// call stack, starting at the topmost application frame (frames of this module and the Go runtime are skipped)
+// and falling back to outer frames.
+//
+// Parameters:
+//   - err: the error to attribute
+//
+// Returns:
+//   - string: the owning team, or an empty string if no registered prefix matches
+func OwnerOf(err error) string {
+	if team, ok := FieldsOf(err)[FieldOwner].(string); ok && team != "" {
+		return team
+	}
+
+	origin := FindOriginalErrorWithStack(err)
+	if origin == nil {
+		return ""
+	}
+
+	ownersMu.RLock()
+	defer ownersMu.RUnlock()
+
+	if len(owners) == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames(*origin.stack)
+
+	for {
+		frame, more := frames.Next()
+
+		if !isLibraryFrame(frame.Function) {
+			if team := ownerOfFrame(frame); team != "" {
+				return team
+			}
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+func ownerOfFrame(frame runtime.Frame) string {
+	team, longest := "", 0
+
+	for prefix, owner := range owners {
+		if len(prefix) > longest && (strings.HasPrefix(frame.File, prefix) || strings.HasPrefix(frame.Function, prefix)) {
+			team, longest = owner, len(prefix)
+		}
+	}
+
+	return team
+}
+
+// isLibraryFrame reports whether the function belongs to this module or the Go runtime.
+func isLibraryFrame(function string) bool {
+	return strings.HasPrefix(function, packagePath+".") ||
+		strings.HasPrefix(function, packagePath+"/") ||
+		strings.HasPrefix(function, "runtime.")
+}
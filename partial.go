@@ -0,0 +1,219 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// Partial holds the outcome of a batch operation that may partially succeed: the values of the items
+	// that succeeded and the errors of those that failed. The zero value is an empty batch ready to use:
+	//
+	//	var result errors.Partial[Item]
+	//	for _, id := range ids {
+	//		item, err := repo.Load(ctx, id)
+	//		if err != nil {
+	//			result.Fail(errors.Annotate(err, errors.WithField(errors.FieldItemKey, id)))
+	//			continue
+	//		}
+	//		result.Add(item)
+	//	}
+	Partial[T any] struct {
+		succeeded []T
+		err       error
+		warnings  Warnings
+	}
+
+	// PartialFailure is the client-safe description of a failed item, as rendered by Partial.MarshalJSON.
+	// The message is the one of the registered definition matching the error, so internal details never reach clients.
+	PartialFailure struct {
+		// Item is the key or index of the failed item, from the FieldItemKey or FieldItemIndex field.
+		Item any `json:"item,omitempty"`
+		// Code is the code of the registered definition matching the error.
+		Code ErrorCode `json:"code"`
+		// Message is the message of the registered definition matching the error.
+		Message string `json:"message"`
+		// Status is the HTTP status the error maps to.
+		Status int `json:"status"`
+		// ReferenceCode is the reference code of the error (see AutoCode).
+		ReferenceCode string `json:"reference_code"`
+		// Instance is the instance ID of the error occurrence (see InstanceID), like the problem details "instance"
+		// member; empty unless instance IDs are enabled.
+		Instance string `json:"instance,omitempty"`
+	}
+
+	partialPayload[T any] struct {
+		Succeeded []T              `json:"succeeded"`
+		Failed    []PartialFailure `json:"failed"`
+		Warnings  []Warning        `json:"warnings,omitempty"`
+	}
+)
+
+// NewPartial builds a Partial from the values of the items that succeeded and the combined error of those
+// that failed, such as the Aggregate returned by WrapAll or WrapEach.
+//
+// Parameters:
+//   - succeeded: the values of the items that succeeded
+//   - err: the error of the items that failed; may be nil
+//
+// Returns:
+//   - *Partial[T]: the partial result
+func NewPartial[T any](succeeded []T, err error) *Partial[T] {
+	p := &Partial[T]{succeeded: succeeded}
+	p.Fail(err)
+
+	return p
+}
+
+// Add records the value of an item that succeeded.
+//
+// Parameters:
+//   - v: the value of the item
+func (p *Partial[T]) Add(v T) {
+	p.succeeded = append(p.succeeded, v)
+}
+
+// Fail records the error of an item that failed; the members of an Aggregate are recorded as separate failures.
+// The warnings attached to err with WithWarnings are recorded as well, and an outcome holding only warnings
+// (see OnlyWarnings) is not a failure.
+//
+// Parameters:
+//   - err: the error of the item; nil is ignored
+func (p *Partial[T]) Fail(err error) {
+	p.warnings = append(p.warnings, WarningsOf(err)...)
+
+	if !OnlyWarnings(err) {
+		p.err = Append(p.err, err)
+	}
+}
+
+// Succeeded returns the values of the items that succeeded.
+//
+// Returns:
+//   - []T: the values in the order they were added
+func (p *Partial[T]) Succeeded() []T {
+	return p.succeeded
+}
+
+// Failed returns the errors of the items that failed.
+//
+// Returns:
+//   - []error: the errors in the order they were recorded
+func (p *Partial[T]) Failed() []error {
+	switch x := p.err.(type) { //nolint:errorlint
+	case nil:
+		return nil
+	case *Aggregate:
+		return x.Errors()
+	default:
+		return []error{x}
+	}
+}
+
+// Warnings returns the warnings of the recorded outcomes, see Fail.
+//
+// Returns:
+//   - Warnings: the warnings in the order they were recorded, or nil if there are none
+func (p *Partial[T]) Warnings() Warnings {
+	return p.warnings
+}
+
+// Err returns the combined error of the items that failed.
+//
+// Returns:
+//   - error: nil if no item failed, the single error if one failed, or an *Aggregate otherwise
+func (p *Partial[T]) Err() error {
+	return p.err
+}
+
+// HTTPStatus returns the status of a response reporting the batch: 200 when every item succeeded,
+// the status of the failures when every item failed with the same status, and 207 Multi-Status otherwise.
+//
+// Returns:
+//   - int: the HTTP status
+func (p *Partial[T]) HTTPStatus() int {
+	failed := p.Failed()
+	if len(failed) == 0 {
+		return http.StatusOK
+	}
+
+	if len(p.succeeded) > 0 {
+		return http.StatusMultiStatus
+	}
+
+	status := HTTPStatusOf(failed[0])
+	for _, err := range failed[1:] {
+		if HTTPStatusOf(err) != status {
+			return http.StatusMultiStatus
+		}
+	}
+
+	return status
+}
+
+// MarshalJSON renders the batch as {"succeeded": [...], "failed": [...], "warnings": [...]}, describing each
+// failure with a PartialFailure and each warning with a scrubbed Warning; "warnings" is omitted when there are none.
+//
+// Returns:
+//   - []byte: the JSON encoding of the batch
+//   - error: an error if the encoding fails
+func (p *Partial[T]) MarshalJSON() ([]byte, error) {
+	payload := partialPayload[T]{Succeeded: p.succeeded, Failed: make([]PartialFailure, 0, len(p.Failed()))}
+	if payload.Succeeded == nil {
+		payload.Succeeded = []T{}
+	}
+
+	for _, err := range p.Failed() {
+		payload.Failed = append(payload.Failed, newPartialFailure(err))
+	}
+
+	payload.Warnings = p.warnings.Render()
+
+	return json.Marshal(payload)
+}
+
+// WriteHTTP writes the batch as a JSON response with the status returned by HTTPStatus.
+//
+// Parameters:
+//   - w: the response writer
+//
+// Returns:
+//   - error: an error if the encoding or the write fails
+func (p *Partial[T]) WriteHTTP(w http.ResponseWriter) error {
+	body, err := p.MarshalJSON()
+	if err != nil {
+		return Wrap(err, "failed to encode partial result")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(p.HTTPStatus())
+
+	if _, err = w.Write(body); err != nil {
+		return Wrap(err, "failed to write partial result")
+	}
+
+	return nil
+}
+
+func newPartialFailure(err error) PartialFailure {
+	def, ok := DefinitionOf(err)
+	if !ok {
+		def, _ = DefinitionOf(ErrInternalServerError)
+	}
+
+	fields := FieldsOf(err)
+
+	item, ok := fields[FieldItemKey]
+	if !ok {
+		item = fields[FieldItemIndex]
+	}
+
+	return PartialFailure{
+		Item:          item,
+		Code:          def.Code,
+		Message:       def.Message,
+		Status:        HTTPStatusOf(err),
+		ReferenceCode: AutoCode(err),
+		Instance:      InstanceID(err),
+	}
+}
@@ -1,5 +1,9 @@
 package errors
 
+import (
+	"net/http"
+)
+
 // errors...
 var (
 	ErrBadRequest           = New("bad request")           // HTTP 400
@@ -11,5 +15,48 @@ var (
 	ErrConflict             = New("conflict request")      // HTTP 409
 	ErrPreconditionFailed   = New("precondition failed")   // HTTP 412
 	ErrValidation           = New("validation failed")     // HTTP 422
+	ErrTooManyRequests      = New("too many requests")     // HTTP 429
+	ErrCanceled             = New("request canceled")      // HTTP 499
 	ErrInternalServerError  = New("internal server error") // HTTP 500
+	ErrTimeout              = New("timeout")               // HTTP 504
+)
+
+// codes of the predefined errors...
+const (
+	CodeBadRequest           ErrorCode = "bad_request"
+	CodeUnauthorized         ErrorCode = "unauthorized"
+	CodeRegistrationRequired ErrorCode = "registration_required"
+	CodePaymentError         ErrorCode = "payment_error"
+	CodeForbiddenAction      ErrorCode = "forbidden"
+	CodeNotFound             ErrorCode = "not_found"
+	CodeConflict             ErrorCode = "conflict"
+	CodePreconditionFailed   ErrorCode = "precondition_failed"
+	CodeValidation           ErrorCode = "validation_failed"
+	CodeTooManyRequests      ErrorCode = "too_many_requests"
+	CodeCanceled             ErrorCode = "canceled"
+	CodeInternalServerError  ErrorCode = "internal_server_error"
+	CodeTimeout              ErrorCode = "timeout"
 )
+
+// StatusClientClosedRequest is the non-standard HTTP status used for requests canceled by the client.
+const StatusClientClosedRequest = 499
+
+func init() { //nolint:gochecknoinits
+	for _, def := range []ErrorDefinition{
+		{Code: CodeBadRequest, Err: ErrBadRequest, HTTPStatus: http.StatusBadRequest},
+		{Code: CodeUnauthorized, Err: ErrUnauthorized, HTTPStatus: http.StatusUnauthorized},
+		{Code: CodeRegistrationRequired, Err: ErrRegistrationRequired, HTTPStatus: http.StatusUnauthorized},
+		{Code: CodePaymentError, Err: ErrPaymentError, HTTPStatus: http.StatusPaymentRequired},
+		{Code: CodeForbiddenAction, Err: ErrForbiddenAction, HTTPStatus: http.StatusForbidden},
+		{Code: CodeNotFound, Err: ErrNotFound, HTTPStatus: http.StatusNotFound, Expected: true},
+		{Code: CodeConflict, Err: ErrConflict, HTTPStatus: http.StatusConflict},
+		{Code: CodePreconditionFailed, Err: ErrPreconditionFailed, HTTPStatus: http.StatusPreconditionFailed},
+		{Code: CodeValidation, Err: ErrValidation, HTTPStatus: http.StatusUnprocessableEntity, Expected: true},
+		{Code: CodeTooManyRequests, Err: ErrTooManyRequests, HTTPStatus: http.StatusTooManyRequests, Retryable: true},
+		{Code: CodeCanceled, Err: ErrCanceled, HTTPStatus: StatusClientClosedRequest},
+		{Code: CodeInternalServerError, Err: ErrInternalServerError, HTTPStatus: http.StatusInternalServerError},
+		{Code: CodeTimeout, Err: ErrTimeout, HTTPStatus: http.StatusGatewayTimeout, Retryable: true},
+	} {
+		RegisterDefinition(def)
+	}
+}
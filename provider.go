@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Provider supplies the environment-dependent values used across the package — timestamps, identifiers
+// and the host name — so tests can make them deterministic and restricted environments can avoid syscalls.
+// Nil functions fall back to their defaults.
+type Provider struct {
+	// Now returns the current time; time.Now by default.
+	Now func() time.Time
+	// NewID returns a new unique identifier; a ULID by default (see EnableInstanceIDs).
+	NewID func() string
+	// Hostname returns the name of the host; os.Hostname by default.
+	Hostname func() (string, error)
+}
+
+var provider atomic.Pointer[Provider] //nolint:gochecknoglobals
+
+// SetProvider replaces the package-level provider of clock, identifiers and host name.
+//
+// Parameters:
+//   - p: the provider to use; functions left nil use their defaults
+func SetProvider(p Provider) {
+	if p.Now == nil {
+		p.Now = time.Now
+	}
+
+	if p.NewID == nil {
+		p.NewID = newULID
+	}
+
+	if p.Hostname == nil {
+		p.Hostname = os.Hostname
+	}
+
+	provider.Store(&p)
+}
+
+// CurrentProvider returns the package-level provider with every function set, for integrations that need
+// the same clock, identifiers and host name as this package.
+//
+// Returns:
+//   - Provider: the current provider
+func CurrentProvider() Provider {
+	if p := provider.Load(); p != nil {
+		return *p
+	}
+
+	return Provider{Now: time.Now, NewID: newULID, Hostname: os.Hostname}
+}
+
+func now() time.Time {
+	return CurrentProvider().Now()
+}
+
+func newID() string {
+	return CurrentProvider().NewID()
+}
+
+func hostname() string {
+	name, err := CurrentProvider().Hostname()
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ReadinessPolicy decides which dependency errors make a service not ready, so health-check endpoints
+// consuming the errors of dependency pings decide readiness consistently. They consult it through IsFatalForReadiness.
+type ReadinessPolicy struct {
+	// Fatal maps sentinel errors to whether they are fatal for readiness; the outermost sentinel found in the chain wins.
+	Fatal map[error]bool
+	// Codes maps error codes to whether they are fatal; it is consulted when no sentinel of Fatal matches.
+	Codes map[ErrorCode]bool
+	// Default tells whether errors matching no rule are fatal. Unmatched retryable errors (see IsRetryable)
+	// are never fatal, since they are expected to resolve by themselves.
+	Default bool
+}
+
+var (
+	readinessPolicy        atomic.Pointer[ReadinessPolicy] //nolint:gochecknoglobals
+	defaultReadinessPolicy = DefaultReadinessPolicy()      //nolint:gochecknoglobals
+)
+
+// DefaultReadinessPolicy returns the policy used until SetReadinessPolicy is called: authentication and
+// authorization failures of a dependency are fatal, since they do not resolve without intervention, while
+// timeouts, throttling and cancellations are transient; other errors are fatal.
+//
+// Returns:
+//   - ReadinessPolicy: the default policy
+func DefaultReadinessPolicy() ReadinessPolicy {
+	return ReadinessPolicy{
+		Fatal: map[error]bool{
+			ErrUnauthorized:          true,
+			ErrForbiddenAction:       true,
+			ErrTimeout:               false,
+			ErrTooManyRequests:       false,
+			ErrCanceled:              false,
+			context.DeadlineExceeded: false,
+			context.Canceled:         false,
+		},
+		Default: true,
+	}
+}
+
+// SetReadinessPolicy replaces the package-level readiness policy consulted by IsFatalForReadiness.
+//
+// Parameters:
+//   - policy: the policy to apply
+func SetReadinessPolicy(policy ReadinessPolicy) {
+	readinessPolicy.Store(&policy)
+}
+
+// IsFatalForReadiness reports whether err, returned by a dependency ping, makes the service not ready
+// according to the package-level readiness policy.
+//
+// Parameters:
+//   - err: the error of the dependency check
+//
+// Returns:
+//   - bool: true if the service should report itself as not ready; false if err is nil
+func IsFatalForReadiness(err error) bool {
+	if policy := readinessPolicy.Load(); policy != nil {
+		return policy.IsFatal(err)
+	}
+
+	return defaultReadinessPolicy.IsFatal(err)
+}
+
+// IsFatal reports whether err makes the service not ready according to the policy.
+//
+// Parameters:
+//   - err: the error of the dependency check
+//
+// Returns:
+//   - bool: the decision of the outermost matching sentinel, else of the error code, else false for retryable
+//     errors and the default otherwise; false if err is nil
+func (p ReadinessPolicy) IsFatal(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if fatal, ok := sentinelLookup(err, p.Fatal); ok {
+		return fatal
+	}
+
+	if fatal, ok := p.Codes[CodeOf(err)]; ok {
+		return fatal
+	}
+
+	if IsRetryable(err) {
+		return false
+	}
+
+	return p.Default
+}
@@ -0,0 +1,236 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type (
+	// ErrorCode is a stable, machine-readable identifier of an error category.
+	ErrorCode string
+
+	// ErrorDefinition describes a registered error: a predefined sentinel or a description template,
+	// together with its code and transport mapping.
+	ErrorDefinition struct {
+		// Code is the stable identifier of the error.
+		Code ErrorCode `json:"code"`
+		// Err is the sentinel error matched with Is; optional for template-only definitions.
+		Err error `json:"-"`
+		// Template is the description template for template-based definitions.
+		Template string `json:"template,omitempty"`
+		// Message is the human-readable summary of the error.
+		Message string `json:"message,omitempty"`
+		// HTTPStatus is the HTTP status code the error maps to.
+		HTTPStatus int `json:"http_status,omitempty"`
+		// Retryable reports whether an operation failing with this error may be retried.
+		Retryable bool `json:"retryable,omitempty"`
+		// MessageKey is the i18n key used to look up the localized message.
+		MessageKey string `json:"message_key,omitempty"`
+		// GRPCCode is the name of the gRPC status code the error maps to, as returned by codes.Code.String()
+		// (e.g. "NotFound"); Catalog derives it from HTTPStatus when empty.
+		GRPCCode string `json:"grpc_code,omitempty"`
+		// Owner is the team owning the error, used to route runbooks and alerts.
+		Owner string `json:"owner,omitempty"`
+		// Expected marks errors used as control flow, whose wrappers skip stack capture (see SetExpectedStackMode).
+		Expected bool `json:"expected,omitempty"`
+	}
+)
+
+var (
+	registryMu  sync.RWMutex      //nolint:gochecknoglobals
+	definitions []ErrorDefinition //nolint:gochecknoglobals
+)
+
+// RegisterDefinition adds an error definition to the package registry, replacing any definition with the same code.
+//
+// Parameters:
+//   - def: the definition to register; its Code must not be empty
+func RegisterDefinition(def ErrorDefinition) {
+	if def.Code == "" {
+		reportMisuse("RegisterDefinition called without code")
+
+		return
+	}
+
+	if _, isSentinel := def.Err.(Sentinel); def.Message == "" && def.Err != nil && !isSentinel { //nolint:errorlint
+		def.Message = def.Err.Error()
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if i := slices.IndexFunc(definitions, func(d ErrorDefinition) bool { return d.Code == def.Code }); i >= 0 {
+		definitions[i] = def
+	} else {
+		definitions = append(definitions, def)
+	}
+
+	refreshExpected(definitions)
+}
+
+// Definitions returns all registered error definitions sorted by code.
+//
+// Returns:
+//   - []ErrorDefinition: a copy of the registered definitions
+func Definitions() []ErrorDefinition {
+	registryMu.RLock()
+	defs := slices.Clone(definitions)
+	registryMu.RUnlock()
+
+	slices.SortFunc(defs, func(a, b ErrorDefinition) int {
+		return strings.Compare(string(a.Code), string(b.Code))
+	})
+
+	return defs
+}
+
+// DefinitionByCode returns the registered definition with the given code, letting clients
+// classify errors received over the wire by their code.
+//
+// Parameters:
+//   - code: the code to look up
+//
+// Returns:
+//   - ErrorDefinition: the definition with the code
+//   - bool: true if a definition was found
+func DefinitionByCode(code ErrorCode) (ErrorDefinition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if i := slices.IndexFunc(definitions, func(d ErrorDefinition) bool { return d.Code == code }); i >= 0 {
+		return definitions[i], true
+	}
+
+	return ErrorDefinition{}, false
+}
+
+// DefinitionOf returns the registered definition matching the error chain, either by explicit code
+// (see WithCode), by sentinel or by description template, preferring the outermost match. Each level of the chain
+// matches a sentinel when it is the sentinel or its own Is method reports so. A warnings-only outcome (see
+// OnlyWarnings) is a success and matches no definition.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - ErrorDefinition: the matching definition
+//   - bool: true if a definition was found
+func DefinitionOf(err error) (ErrorDefinition, bool) {
+	if err == nil || OnlyWarnings(err) {
+		return ErrorDefinition{}, false
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for e := range Chain(err) {
+		frameworkErr, isFrameworkErr := e.(*Error) //nolint:errorlint
+
+		for _, def := range definitions {
+			if isFrameworkErr && frameworkErr.code != "" && frameworkErr.code == def.Code {
+				return def, true
+			}
+
+			if def.Err != nil && matchesSentinel(e, def.Err) {
+				return def, true
+			}
+
+			if sentinel, ok := e.(Sentinel); ok && sentinel.code == def.Code { //nolint:errorlint
+				return def, true
+			}
+
+			if isFrameworkErr && def.Template != "" && frameworkErr.template == def.Template {
+				return def, true
+			}
+		}
+	}
+
+	return ErrorDefinition{}, false
+}
+
+// CodeOf returns the code of the error: the outermost code set explicitly with WithCode,
+// or else the code of the registered definition matching the error chain.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - ErrorCode: the matching code, or an empty code if the error is not classified
+func CodeOf(err error) ErrorCode {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.code != "" { //nolint:errorlint
+			return frameworkErr.code
+		}
+
+		if sentinel, ok := e.(Sentinel); ok { //nolint:errorlint
+			return sentinel.code
+		}
+	}
+
+	def, _ := DefinitionOf(err)
+
+	return def.Code
+}
+
+// HTTPStatusOf returns the HTTP status of the registered definition matching the error chain.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - int: the matching HTTP status, 200 for a warnings-only outcome (see OnlyWarnings), or 500 if the error is
+//     not classified
+func HTTPStatusOf(err error) int {
+	if OnlyWarnings(err) {
+		return http.StatusOK
+	}
+
+	if def, ok := DefinitionOf(err); ok && def.HTTPStatus != 0 {
+		return def.HTTPStatus
+	}
+
+	return http.StatusInternalServerError
+}
+
+// IsRetryable reports whether the registered definition matching the error chain is retryable.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - bool: true if the error is classified as retryable
+func IsRetryable(err error) bool {
+	def, _ := DefinitionOf(err)
+
+	return def.Retryable
+}
+
+// MustClassify verifies that an error leaving the handler layer carries a registered classification,
+// enforcing the taxonomy across a codebase. Unclassified errors are logged (or panic under StrictPanic,
+// failing the test) and returned classified as ErrInternalServerError.
+//
+// Parameters:
+//   - err: the error to verify
+//
+// Returns:
+//   - error: err unchanged if it is classified or holds only warnings (see OnlyWarnings), err classified as
+//     ErrInternalServerError otherwise, or nil if err is nil
+func MustClassify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := DefinitionOf(err); ok || OnlyWarnings(err) {
+		return err
+	}
+
+	reportViolation("unclassified error %q left the handler layer", err)
+
+	return track(&Error{
+		stack: callers(),
+		error: fmt.Errorf("%w: %w", ErrInternalServerError, err),
+	})
+}
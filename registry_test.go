@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+type quotaError struct{ tenant string }
+
+func (e quotaError) Error() string { return "quota exceeded for " + e.tenant }
+
+func (quotaError) Is(target error) bool { return target == errQuota } //nolint:errorlint
+
+var errQuota = errors.NewSentinel("test_quota_exceeded")
+
+func init() {
+	errors.RegisterDefinition(errors.ErrorDefinition{
+		Code: "test_quota_exceeded", Err: errQuota, Message: "quota exceeded", HTTPStatus: http.StatusTooManyRequests,
+	})
+}
+
+func TestDefinitionOfMatchesCustomIs(t *testing.T) {
+	t.Parallel()
+
+	def, ok := errors.DefinitionOf(errors.Wrap(quotaError{tenant: "acme"}, "charge"))
+	if !ok || def.Code != "test_quota_exceeded" {
+		t.Fatalf("DefinitionOf = %q, %v, want test_quota_exceeded", def.Code, ok)
+	}
+}
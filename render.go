@@ -0,0 +1,232 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// DefaultSeparator is the separator placed between messages of an error chain.
+	DefaultSeparator = ": "
+	// DefaultJoinedSeparator is the separator placed between the members of a joined error, as errors.Join does.
+	DefaultJoinedSeparator = "\n"
+	// truncationMarker terminates chains cut short by MaxChainLength.
+	truncationMarker = "..."
+)
+
+type (
+	// RenderOption customizes how an error chain is rendered into a single message.
+	RenderOption func(*renderConfig)
+
+	renderConfig struct {
+		separator         string
+		includeDuplicates bool
+		maxChainLength    int
+		joinedSeparator   string
+		joinedCount       bool
+	}
+)
+
+var renderDefaults atomic.Pointer[renderConfig] //nolint:gochecknoglobals
+
+// Separator sets the string placed between the messages of consecutive chain levels.
+//
+// Parameters:
+//   - separator: the separator to use (DefaultSeparator by default)
+//
+// Returns:
+//   - RenderOption: the option applying the separator
+func Separator(separator string) RenderOption {
+	return func(c *renderConfig) {
+		c.separator = separator
+	}
+}
+
+// IncludeDuplicates controls whether identical adjacent messages are kept when rendering a chain.
+//
+// Parameters:
+//   - include: true to keep duplicates (the default), false to collapse them
+//
+// Returns:
+//   - RenderOption: the option applying the duplicate handling
+func IncludeDuplicates(include bool) RenderOption {
+	return func(c *renderConfig) {
+		c.includeDuplicates = include
+	}
+}
+
+// MaxChainLength limits the number of chain levels rendered; the rest is replaced by "...".
+//
+// Parameters:
+//   - n: the maximum number of messages to render; zero or less means unlimited (the default)
+//
+// Returns:
+//   - RenderOption: the option applying the limit
+func MaxChainLength(n int) RenderOption {
+	return func(c *renderConfig) {
+		c.maxChainLength = n
+	}
+}
+
+// JoinedSeparator sets the string placed between the members of a joined error (see errors.Join and Aggregate),
+// e.g. "; " to keep messages on a single line for log pipelines. It only affects display, not unwrapping.
+//
+// Parameters:
+//   - separator: the separator to use (DefaultJoinedSeparator by default)
+//
+// Returns:
+//   - RenderOption: the option applying the separator
+func JoinedSeparator(separator string) RenderOption {
+	return func(c *renderConfig) {
+		c.joinedSeparator = separator
+	}
+}
+
+// JoinedCount controls whether the members of a joined error are prefixed with their count, e.g. "2 errors: a; b".
+//
+// Parameters:
+//   - enabled: true to prefix the count, false to render the members only (the default)
+//
+// Returns:
+//   - RenderOption: the option applying the count prefix
+func JoinedCount(enabled bool) RenderOption {
+	return func(c *renderConfig) {
+		c.joinedCount = enabled
+	}
+}
+
+// SetRenderDefaults replaces the package-level rendering options used by Error(), %+v, JSON and reporters.
+// Options not provided fall back to their defaults.
+//
+// Parameters:
+//   - opts: the rendering options to apply
+func SetRenderDefaults(opts ...RenderOption) {
+	cfg := defaultRenderConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	renderDefaults.Store(&cfg)
+}
+
+// Render renders the chain of err into a single message using the package-level defaults
+// overridden by the given options.
+//
+// Parameters:
+//   - err: the error to render; if nil, an empty string is returned
+//   - opts: rendering options overriding the package-level defaults for this call
+//
+// Returns:
+//   - string: the rendered message
+func Render(err error, opts ...RenderOption) string {
+	if err == nil {
+		return ""
+	}
+
+	cfg := currentRenderConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return renderChain(err, cfg)
+}
+
+func defaultRenderConfig() renderConfig {
+	return renderConfig{
+		separator:         DefaultSeparator,
+		includeDuplicates: true,
+		joinedSeparator:   DefaultJoinedSeparator,
+	}
+}
+
+func currentRenderConfig() renderConfig {
+	if cfg := renderDefaults.Load(); cfg != nil {
+		return *cfg
+	}
+
+	return defaultRenderConfig()
+}
+
+func renderChain(err error, cfg renderConfig) string {
+	segments := chainSegments(err, cfg)
+
+	if !cfg.includeDuplicates {
+		deduplicated := make([]string, 0, len(segments))
+		for i, segment := range segments {
+			if i > 0 && segment == segments[i-1] {
+				continue
+			}
+
+			deduplicated = append(deduplicated, segment)
+		}
+
+		segments = deduplicated
+	}
+
+	if cfg.maxChainLength > 0 && len(segments) > cfg.maxChainLength {
+		segments = append(segments[:cfg.maxChainLength], truncationMarker)
+	}
+
+	return strings.Join(segments, cfg.separator)
+}
+
+// chainSegments splits the chain of err into the messages contributed by each level.
+// Framework errors contribute their description, while the first foreign error contributes
+// its full message since it cannot be split further.
+func chainSegments(err error, cfg renderConfig) []string {
+	var segments []string
+
+	for current := err; current != nil; {
+		frameworkErr, ok := current.(*Error) //nolint:errorlint
+		if !ok {
+			segments = append(segments, renderForeign(current, cfg))
+
+			break
+		}
+
+		if frameworkErr.Description != "" {
+			segments = append(segments, frameworkErr.Description)
+		}
+
+		current = frameworkErr.error
+	}
+
+	return segments
+}
+
+// renderForeign renders the message of a foreign error, rendering the members of a joined error
+// with the configured separator and count prefix. Errors wrapping several errors whose message is not
+// the newline-joined message of the members, such as fmt.Errorf with several %w verbs, are rendered as is.
+func renderForeign(err error, cfg renderConfig) string {
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if !ok || (cfg.joinedSeparator == DefaultJoinedSeparator && !cfg.joinedCount) {
+		return err.Error()
+	}
+
+	members := make([]error, 0, len(joined.Unwrap()))
+	messages := make([]string, 0, cap(members))
+
+	for _, member := range joined.Unwrap() {
+		if member != nil {
+			members = append(members, member)
+			messages = append(messages, member.Error())
+		}
+	}
+
+	message := err.Error()
+	if message != strings.Join(messages, DefaultJoinedSeparator) {
+		return message
+	}
+
+	for i, member := range members {
+		messages[i] = renderChain(member, cfg)
+	}
+
+	rendered := strings.Join(messages, cfg.joinedSeparator)
+	if cfg.joinedCount {
+		rendered = strconv.Itoa(len(messages)) + " errors: " + rendered
+	}
+
+	return rendered
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// ReportBudget bounds the time a reporter spends on a single report, so reporting never adds unbounded latency
+	// to the request path. Reporters create it with NewReportBudget when a report starts, send the essentials of
+	// the error first, then add the costly details (stack, fields, attachments...) only while the budget is not
+	// exceeded, degrading to a compact report otherwise.
+	ReportBudget struct {
+		deadline time.Time
+		limited  bool
+	}
+)
+
+var maxReportLatency atomic.Int64 //nolint:gochecknoglobals
+
+// SetMaxReportLatency sets the global maximum time a reporter may spend on a report; reports exceeding it are
+// degraded to compact reports. The default, 0, sets no limit. Reporters check the budget between the steps of a
+// report, so a single step that blocks, such as a tracer call, is not interrupted and may still exceed the limit.
+//
+// Parameters:
+//   - latency: the maximum report latency; 0 or less removes the limit
+func SetMaxReportLatency(latency time.Duration) {
+	maxReportLatency.Store(int64(max(latency, 0)))
+}
+
+// MaxReportLatency returns the global maximum report latency set with SetMaxReportLatency.
+//
+// Returns:
+//   - time.Duration: the maximum report latency, or 0 if there is no limit
+func MaxReportLatency() time.Duration {
+	return time.Duration(maxReportLatency.Load())
+}
+
+// WithReportDeadline sets the deadline of the reports made with the returned context, e.g. a tighter one on
+// latency-sensitive paths. It is independent of the deadline of ctx itself, since errors are usually reported
+// once the request context is done; the earliest of the report deadline and the global maximum report latency
+// applies.
+//
+// Parameters:
+//   - ctx: the parent context to derive from
+//   - deadline: the time by which the reports must be done
+//
+// Returns:
+//   - context.Context: derived context carrying the report deadline
+func WithReportDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, reportDeadlineKey, deadline)
+}
+
+// NewReportBudget starts the budget of a report made with ctx: it expires at the deadline set with
+// WithReportDeadline, or once the global maximum report latency has elapsed, whichever comes first.
+//
+// Parameters:
+//   - ctx: the context of the report
+//
+// Returns:
+//   - ReportBudget: the budget of the report, unlimited if neither a deadline nor a maximum latency is set
+func NewReportBudget(ctx context.Context) ReportBudget {
+	var budget ReportBudget
+
+	if deadline, ok := ctx.Value(reportDeadlineKey).(time.Time); ok {
+		budget = ReportBudget{deadline: deadline, limited: true}
+	}
+
+	if latency := MaxReportLatency(); latency > 0 {
+		if deadline := now().Add(latency); !budget.limited || deadline.Before(budget.deadline) {
+			budget = ReportBudget{deadline: deadline, limited: true}
+		}
+	}
+
+	return budget
+}
+
+// Deadline returns the time by which the report must be done.
+//
+// Returns:
+//   - time.Time: the deadline of the report
+//   - bool: false if the budget is unlimited
+func (b ReportBudget) Deadline() (time.Time, bool) {
+	return b.deadline, b.limited
+}
+
+// Exceeded reports whether the deadline of the report has passed, in which case the reporter should send
+// what it has and skip the remaining details.
+//
+// Returns:
+//   - bool: true if the budget is limited and its deadline has passed
+func (b ReportBudget) Exceeded() bool {
+	return b.limited && !now().Before(b.deadline)
+}
+
+// Context bounds ctx by the deadline of the report, for the network calls a reporter makes, e.g. uploading
+// attachments.
+//
+// Parameters:
+//   - ctx: the context of the report
+//
+// Returns:
+//   - context.Context: ctx, with the deadline of the report if the budget is limited
+//   - context.CancelFunc: releases the resources of the derived context
+func (b ReportBudget) Context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if !b.limited {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithDeadline(ctx, b.deadline)
+}
@@ -0,0 +1,129 @@
+package errors
+
+type (
+	// Result holds the outcome of a pipeline step: a value or the error that stopped the pipeline.
+	// Once a step fails, the following steps are skipped and the error is carried to Unwrap:
+	//
+	//	user, err := errors.Try(repo.Load(ctx, id)).Map(normalize).Map(validate).Unwrap()
+	Result[T any] struct {
+		value T
+		err   error
+	}
+)
+
+// Try starts a pipeline from the results of a call, wrapping a failure with a call stack
+// unless an error of its chain already carries one.
+//
+// Parameters:
+//   - v: the value returned by the call
+//   - err: the error returned by the call
+//
+// Returns:
+//   - Result[T]: the result holding v, or the error
+func Try[T any](v T, err error) Result[T] {
+	return Result[T]{value: v, err: withStepStack(err)}
+}
+
+// Then applies a step changing the type of the value, unless the result already failed.
+// A failure of the step is wrapped with a call stack like in Try.
+//
+// Parameters:
+//   - r: the result of the previous step
+//   - fn: the step to apply to the value
+//
+// Returns:
+//   - Result[U]: the result of the step, or the error of r
+func Then[T, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+
+	value, err := fn(r.value)
+
+	return Result[U]{value: value, err: withStepStack(err)}
+}
+
+// Map applies a step to the value, unless the result already failed. A failure of the step is wrapped
+// with a call stack unless an error of its chain already carries one.
+//
+// Parameters:
+//   - fn: the step to apply to the value
+//
+// Returns:
+//   - Result[T]: the result of the step, or the receiver if it failed
+func (r Result[T]) Map(fn func(T) (T, error)) Result[T] {
+	if r.err != nil {
+		return r
+	}
+
+	value, err := fn(r.value)
+
+	return Result[T]{value: value, err: withStepStack(err)}
+}
+
+// Wrap adds context to the error of a failed result, leaving a successful result untouched.
+//
+// Parameters:
+//   - description: a description providing context for the error
+//
+// Returns:
+//   - Result[T]: the result with the wrapped error
+func (r Result[T]) Wrap(description string) Result[T] {
+	if r.err == nil {
+		return r
+	}
+
+	return Result[T]{err: track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(r.err, 0),
+		error:       r.err,
+	})}
+}
+
+// OrElse returns the value, or fallback if the result failed.
+//
+// Parameters:
+//   - fallback: the value returned on failure
+//
+// Returns:
+//   - T: the value or the fallback
+func (r Result[T]) OrElse(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+
+	return r.value
+}
+
+// Err returns the error of the result.
+//
+// Returns:
+//   - error: the error, or nil if every step succeeded
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap ends the pipeline, returning the value and the error.
+//
+// Returns:
+//   - T: the value, or the zero value if the result failed
+//   - error: the error, or nil if every step succeeded
+func (r Result[T]) Unwrap() (T, error) {
+	if r.err != nil {
+		var zero T
+
+		return zero, r.err
+	}
+
+	return r.value, nil
+}
+
+// withStepStack wraps the error of a pipeline step with the call stack of the caller of the pipeline function,
+// unless an error of its chain already carries one.
+func withStepStack(err error) error {
+	if err == nil || FindOriginalErrorWithStack(err) != nil {
+		return err
+	}
+
+	return track(&Error{stack: stackFor(err, 1), error: err})
+}
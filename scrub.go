@@ -0,0 +1,145 @@
+package errors
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// ScrubKeyMessage is the key scrubbers receive error messages and descriptions under.
+	ScrubKeyMessage = "message"
+	// ScrubKeyAttachmentPrefix prefixes the attachment name in the key scrubbers receive attachment data under.
+	ScrubKeyAttachmentPrefix = "attachment."
+)
+
+type (
+	// Scrubber redacts sensitive data before it is serialized or reported. It receives a field key (or one of the
+	// ScrubKey* keys for messages and attachments) with its value, and returns the replacement value and true
+	// when the value must be replaced, or false to keep it unchanged.
+	Scrubber func(key string, value any) (any, bool)
+)
+
+var (
+	scrubbersMu sync.Mutex                 //nolint:gochecknoglobals
+	scrubbers   atomic.Pointer[[]Scrubber] //nolint:gochecknoglobals
+)
+
+// RegisterScrubber adds a scrubber applied to fields, messages, attachments and reporter request data before
+// any serialization or reporting, so compliance redaction happens once instead of in every sink.
+// Scrubbers run in registration order, each one receiving the value returned by the previous one.
+//
+// Parameters:
+//   - scrubber: the scrubber to add; nil is ignored
+func RegisterScrubber(scrubber Scrubber) {
+	if scrubber == nil {
+		return
+	}
+
+	scrubbersMu.Lock()
+	defer scrubbersMu.Unlock()
+
+	var registered []Scrubber
+	if current := scrubbers.Load(); current != nil {
+		registered = slices.Clone(*current)
+	}
+
+	registered = append(registered, scrubber)
+	scrubbers.Store(&registered)
+}
+
+// Scrub applies the registered scrubbers to a value. Sinks outside this package call it on the data they emit.
+//
+// Parameters:
+//   - key: the field key, or one of the ScrubKey* keys
+//   - value: the value to scrub
+//
+// Returns:
+//   - any: the scrubbed value
+func Scrub(key string, value any) any {
+	registered := scrubbers.Load()
+	if registered == nil {
+		return value
+	}
+
+	for _, scrubber := range *registered {
+		if replacement, ok := scrubber(key, value); ok {
+			value = replacement
+		}
+	}
+
+	return value
+}
+
+// ScrubMessage applies the registered scrubbers to an error message under ScrubKeyMessage.
+//
+// Parameters:
+//   - message: the message to scrub
+//
+// Returns:
+//   - string: the scrubbed message
+func ScrubMessage(message string) string {
+	return ScrubString(ScrubKeyMessage, message)
+}
+
+// ScrubFields applies the registered scrubbers to each field.
+//
+// Parameters:
+//   - fields: the fields to scrub; they are not modified
+//
+// Returns:
+//   - Fields: a scrubbed copy of the fields, or nil if there are none
+func ScrubFields(fields Fields) Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	scrubbed := make(Fields, len(fields))
+	for key, value := range fields {
+		scrubbed[key] = Scrub(key, value)
+	}
+
+	return scrubbed
+}
+
+// ScrubAttachment applies the registered scrubbers to the data of an attachment,
+// under ScrubKeyAttachmentPrefix followed by the attachment name.
+//
+// Parameters:
+//   - attachment: the attachment to scrub; it is not modified
+//
+// Returns:
+//   - Attachment: the scrubbed attachment
+func ScrubAttachment(attachment Attachment) Attachment {
+	switch scrubbed := Scrub(ScrubKeyAttachmentPrefix+attachment.Name, attachment.Data).(type) {
+	case []byte:
+		attachment.Data = scrubbed
+	case string:
+		attachment.Data = []byte(scrubbed)
+	default:
+		attachment.Data = []byte(fmt.Sprint(scrubbed))
+	}
+
+	return attachment
+}
+
+// ScrubString applies the registered scrubbers to a string value, such as request data attached by reporters.
+//
+// Parameters:
+//   - key: the key identifying the value, e.g. "request.uri"
+//   - value: the value to scrub
+//
+// Returns:
+//   - string: the scrubbed value, or Redacted if a scrubber replaced it with a non-string value
+func ScrubString(key, value string) string {
+	if value == "" {
+		return value
+	}
+
+	if scrubbed, ok := Scrub(key, value).(string); ok {
+		return scrubbed
+	}
+
+	return Redacted
+}
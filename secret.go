@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// Redacted is the placeholder rendered in place of secret values.
+const Redacted = "[REDACTED]"
+
+// SecretValue holds a sensitive value that renders as [REDACTED] whenever it is formatted, serialized or logged.
+type SecretValue struct {
+	value any
+}
+
+// Secret marks a value as sensitive, so tokens and credentials interpolated into messages by Newf and Wrapf
+// or stored in fields never leak through messages, JSON or reports:
+//
+//	return errors.Wrapf(err, "authenticating with token %s", errors.Secret(token))
+//
+// Parameters:
+//   - v: the sensitive value
+//
+// Returns:
+//   - SecretValue: the wrapped value
+func Secret(v any) SecretValue {
+	return SecretValue{value: v}
+}
+
+// Reveal returns the wrapped value. It is meant for debugging in local development only.
+//
+// Returns:
+//   - any: the sensitive value
+func (s SecretValue) Reveal() any {
+	return s.value
+}
+
+// String implements fmt.Stringer.
+func (s SecretValue) String() string {
+	return Redacted
+}
+
+// Format implements fmt.Formatter, redacting the value for every verb, including %#v.
+func (s SecretValue) Format(f fmt.State, _ rune) {
+	_, _ = fmt.Fprint(f, Redacted) //nolint:errcheck,revive
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SecretValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// LogValue implements slog.LogValuer.
+func (s SecretValue) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
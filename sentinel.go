@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"strings"
+)
+
+type (
+	// Sentinel is an error identified by its code alone. Its message comes from the registered definition
+	// with that code, so messages can be reworded without breaking Is comparisons or fingerprints.
+	// Sentinels are comparable values: two sentinels with the same code are equal.
+	Sentinel struct {
+		code ErrorCode
+	}
+)
+
+// NewSentinel returns the sentinel error identified by code. Register a definition with the code
+// to give it a message, HTTP status and other metadata:
+//
+//	var ErrQuotaExceeded = errors.NewSentinel("quota_exceeded")
+//
+//	func init() {
+//		errors.RegisterDefinition(errors.ErrorDefinition{
+//			Code: "quota_exceeded", Err: ErrQuotaExceeded, Message: "quota exceeded", HTTPStatus: http.StatusTooManyRequests,
+//		})
+//	}
+//
+// Parameters:
+//   - code: the code identifying the sentinel
+//
+// Returns:
+//   - Sentinel: the sentinel error
+func NewSentinel(code ErrorCode) Sentinel {
+	if code == "" {
+		reportMisuse("NewSentinel called without code")
+	}
+
+	return Sentinel{code: code}
+}
+
+// Code returns the code identifying the sentinel.
+//
+// Returns:
+//   - ErrorCode: the code of the sentinel
+func (s Sentinel) Code() ErrorCode {
+	return s.code
+}
+
+// Error returns the message of the definition registered with the sentinel's code, or else its
+// message key or the code itself.
+//
+// Returns:
+//   - string: the message of the sentinel
+func (s Sentinel) Error() string {
+	if def, ok := DefinitionByCode(s.code); ok {
+		switch {
+		case def.Message != "":
+			return def.Message
+		case def.MessageKey != "":
+			return def.MessageKey
+		}
+	}
+
+	return string(s.code)
+}
+
+// stableMessage returns the message of err with the text of the sentinels in its chain replaced by their codes,
+// so rewording a sentinel's message does not change the fingerprint of the errors classified with it.
+func stableMessage(err error) string {
+	message := err.Error()
+
+	for e := range Chain(err) {
+		if sentinel, ok := e.(Sentinel); ok { //nolint:errorlint
+			if text := sentinel.Error(); text != string(sentinel.code) {
+				message = strings.ReplaceAll(message, text, "{"+string(sentinel.code)+"}")
+			}
+		}
+	}
+
+	return message
+}
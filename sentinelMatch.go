@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"reflect"
+)
+
+// matchesSentinel reports whether the level e of a chain is sentinel itself, or claims to be through its own
+// Is method, without looking at the levels below e, so the caller keeps preferring the outermost match.
+func matchesSentinel(e, sentinel error) bool {
+	// Comparisons panic on errors of the same non-comparable dynamic type.
+	if reflect.TypeOf(e).Comparable() && e == sentinel { //nolint:errorlint
+		return true
+	}
+
+	matcher, ok := e.(interface{ Is(target error) bool }) //nolint:errorlint
+
+	return ok && matcher.Is(sentinel)
+}
+
+// sentinelLookup returns the value configured for the outermost level of the chain of err that matches a key of
+// values (see matchesSentinel), for the policies keyed on sentinels.
+func sentinelLookup[T any](err error, values map[error]T) (T, bool) {
+	for e := range Chain(err) {
+		// Map lookups panic on errors of non-comparable dynamic types.
+		if reflect.TypeOf(e).Comparable() {
+			if value, ok := values[e]; ok {
+				return value, true
+			}
+		}
+
+		if matcher, ok := e.(interface{ Is(target error) bool }); ok { //nolint:errorlint
+			for sentinel, value := range values {
+				if matcher.Is(sentinel) {
+					return value, true
+				}
+			}
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
@@ -0,0 +1,54 @@
+package errors
+
+// Shrink returns a copy of err without call stacks and attachments, for errors stored or cached long-term, such as
+// negative cache entries, which would otherwise pin their stack and attachment data. Every level keeps its
+// description, code, template, fields, tags, upstream and instance ID, so messages, classification and Is keep
+// working; named causes, warnings and aggregate members are shrunk as well. Registered sentinels and errors of other
+// packages are kept unchanged, except wrappers holding errors of this package, such as fmt.Errorf or errors.Join
+// values, which are rebuilt around the shrunk errors with the same message. Without a stack, AutoCode derives the
+// reference code from the message only.
+//
+// Parameters:
+//   - err: the error to shrink
+//
+// Returns:
+//   - error: the shrunk copy, or nil if err is nil
+func Shrink(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	walker := &chainWalker{isSentinel: isRegisteredSentinel(), join: func(members ...error) error {
+		return &Aggregate{errs: members}
+	}}
+	walker.level = func(e *Error, _ func(error) (error, bool)) error {
+		shrunk := *e
+		shrunk.stack = nil
+		shrunk.attachments = nil
+		shrunk.error = walker.rebuild(e.error)
+
+		if len(e.causes) > 0 {
+			shrunk.causes = make(map[string]error, len(e.causes))
+			for name, cause := range e.causes {
+				shrunk.causes[name] = walker.rebuild(cause)
+			}
+		}
+
+		if len(e.warnings) > 0 {
+			shrunk.warnings = make(Warnings, 0, len(e.warnings))
+			for _, warning := range e.warnings {
+				shrunk.warnings = append(shrunk.warnings, walker.rebuild(warning))
+			}
+		}
+
+		if e.wire != nil {
+			wire := *e.wire
+			wire.stack = nil
+			shrunk.wire = &wire
+		}
+
+		return &shrunk
+	}
+
+	return walker.rebuild(err)
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Flusher drains the buffered state of an asynchronous sink (reporter queue, sampler, metrics)
+	// before the service stops, returning once done or when ctx is done.
+	Flusher func(ctx context.Context) error
+)
+
+var (
+	flushersMu sync.Mutex                //nolint:gochecknoglobals
+	flushers   atomic.Pointer[[]Flusher] //nolint:gochecknoglobals
+)
+
+// RegisterFlusher adds a flusher run by Shutdown. Asynchronous sinks register one when they are created,
+// so a single Shutdown call flushes every reporter. Flushers run in registration order.
+//
+// Parameters:
+//   - flusher: the flusher to add; nil is ignored
+func RegisterFlusher(flusher Flusher) {
+	if flusher == nil {
+		return
+	}
+
+	flushersMu.Lock()
+	defer flushersMu.Unlock()
+
+	var registered []Flusher
+	if current := flushers.Load(); current != nil {
+		registered = slices.Clone(*current)
+	}
+
+	registered = append(registered, flusher)
+	flushers.Store(&registered)
+}
+
+// Shutdown prepares the package for a clean service shutdown: it waits for the goroutines started with Go
+// and GoContext, so their failures are reported, then runs the registered flushers, all within the deadline of ctx.
+//
+// Parameters:
+//   - ctx: the context bounding the shutdown
+//
+// Returns:
+//   - error: the failures of the flushers, or the error of ctx if the deadline was reached, or nil
+func Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		goroutines.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return Wrap(ctx.Err(), "shutdown interrupted while waiting for goroutines")
+	}
+
+	var err error
+
+	if registered := flushers.Load(); registered != nil {
+		for _, flush := range *registered {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return Append(err, Wrap(ctxErr, "shutdown interrupted while flushing"))
+			}
+
+			if flushErr := flush(ctx); flushErr != nil {
+				err = Append(err, Wrap(flushErr, "failed to flush"))
+			}
+		}
+	}
+
+	return err
+}
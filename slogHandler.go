@@ -0,0 +1,202 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+type (
+	// SlogOption customizes the handler returned by NewSlogHandler.
+	SlogOption func(*slogConfig)
+
+	slogConfig struct {
+		reporter    Reporter
+		reportLevel slog.Leveler
+		omitStack   bool
+	}
+
+	// slogHandler expands the error attributes of records before passing them to the next handler.
+	slogHandler struct {
+		next slog.Handler
+		cfg  slogConfig
+	}
+)
+
+// SlogReporter forwards the errors logged in records at or above level to reporter, e.g. datadog.HandleError,
+// so applications logging errors rather than reporting them still feed the reporting backend.
+//
+// Parameters:
+//   - reporter: the reporter receiving the logged errors
+//   - level: the minimum level of the records whose errors are reported, e.g. slog.LevelError
+//
+// Returns:
+//   - SlogOption: the option forwarding errors to the reporter
+func SlogReporter(reporter Reporter, level slog.Leveler) SlogOption {
+	return func(c *slogConfig) {
+		c.reporter = reporter
+		c.reportLevel = level
+	}
+}
+
+// SlogStack controls whether the expanded errors include their call stack.
+//
+// Parameters:
+//   - include: true to include the stack (the default), false to omit it
+//
+// Returns:
+//   - SlogOption: the option applying the stack rendering
+func SlogStack(include bool) SlogOption {
+	return func(c *slogConfig) {
+		c.omitStack = !include
+	}
+}
+
+// NewSlogHandler wraps next with a handler expanding the error values of attributes into structured groups holding
+// the message, the chain of messages, the code, the reference code, the fields and the call stack of the error:
+//
+//	logger := slog.New(errors.NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil),
+//		errors.SlogReporter(datadog.HandleError, slog.LevelError)))
+//	logger.Error("charge failed", "err", err) // "err": {"message": ..., "code": ..., "fields": {...}, "stack": [...]}
+//
+// Messages and fields are scrubbed (see ScrubMessage and ScrubFields).
+//
+// Parameters:
+//   - next: the handler receiving the expanded records
+//   - opts: options customizing the expansion and reporting
+//
+// Returns:
+//   - slog.Handler: the wrapping handler
+func NewSlogHandler(next slog.Handler, opts ...SlogOption) slog.Handler {
+	cfg := slogConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &slogHandler{next: next, cfg: cfg}
+}
+
+// LogValue returns the structured group NewSlogHandler expands err into, for loggers without the handler:
+//
+//	logger.Error("charge failed", slog.Any("err", errors.LogValue(err)))
+//
+// Parameters:
+//   - err: the error to expand
+//
+// Returns:
+//   - slog.Value: the group describing the error
+func LogValue(err error) slog.Value {
+	return logValue(err, slogConfig{})
+}
+
+// Enabled implements slog.Handler.
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler, expanding the error attributes of the record and reporting their errors.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var logged []error
+
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(h.expand(attr, &logged))
+
+		return true
+	})
+
+	if h.cfg.reporter != nil && h.cfg.reportLevel != nil && record.Level >= h.cfg.reportLevel.Level() {
+		for _, err := range logged {
+			_ = h.cfg.reporter(ctx, err) //nolint:errcheck
+		}
+	}
+
+	return h.next.Handle(ctx, expanded) //nolint:wrapcheck
+}
+
+// WithAttrs implements slog.Handler. Errors of logger attributes are expanded but not reported,
+// since they would be reported with every record.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, h.expand(attr, nil))
+	}
+
+	return &slogHandler{next: h.next.WithAttrs(expanded), cfg: h.cfg}
+}
+
+// WithGroup implements slog.Handler.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{next: h.next.WithGroup(name), cfg: h.cfg}
+}
+
+// expand replaces the error values of attr, including those nested in groups, collecting the errors into logged.
+func (h *slogHandler) expand(attr slog.Attr, logged *[]error) slog.Attr {
+	value := attr.Value.Resolve()
+
+	switch value.Kind() { //nolint:exhaustive
+	case slog.KindAny:
+		err, ok := value.Any().(error)
+		if !ok || err == nil {
+			return attr
+		}
+
+		if logged != nil {
+			*logged = append(*logged, err)
+		}
+
+		return slog.Attr{Key: attr.Key, Value: logValue(err, h.cfg)}
+	case slog.KindGroup:
+		group := value.Group()
+		expanded := make([]slog.Attr, 0, len(group))
+
+		for _, member := range group {
+			expanded = append(expanded, h.expand(member, logged))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	default:
+		return attr
+	}
+}
+
+func logValue(err error, cfg slogConfig) slog.Value {
+	if err == nil {
+		return slog.Value{}
+	}
+
+	attrs := []slog.Attr{slog.String("message", ScrubMessage(err.Error()))}
+
+	if segments := chainSegments(err, currentRenderConfig()); len(segments) > 1 {
+		for i, segment := range segments {
+			segments[i] = ScrubMessage(segment)
+		}
+
+		attrs = append(attrs, slog.Any("chain", segments))
+	}
+
+	if code := CodeOf(err); code != "" {
+		attrs = append(attrs, slog.String("code", string(code)))
+	}
+
+	attrs = append(attrs, slog.String(FieldReferenceCode, AutoCode(err)))
+
+	if fields := ScrubFields(FieldsOf(err)); len(fields) > 0 {
+		group := make([]slog.Attr, 0, len(fields))
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			group = append(group, slog.Any(key, fields[key]))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(group...)})
+	}
+
+	if !cfg.omitStack {
+		if stack := FindOriginalErrorWithStack(err).GetCallStack(); len(stack) > 0 {
+			attrs = append(attrs, slog.Any("stack", stack))
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
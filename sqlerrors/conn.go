@@ -0,0 +1,347 @@
+package sqlerrors
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"time"
+)
+
+type (
+	wrappedConn struct {
+		driver.Conn
+
+		cfg *config
+	}
+
+	wrappedStmt struct {
+		driver.Stmt
+
+		// conn is the unwrapped connection, whose NamedValueChecker database/sql would use without the wrapper.
+		conn  driver.Conn
+		cfg   *config
+		query string
+	}
+
+	wrappedTx struct {
+		driver.Tx
+
+		cfg *config
+	}
+
+	wrappedRows struct {
+		driver.Rows
+
+		cfg   *config
+		query string
+		start time.Time
+	}
+)
+
+var (
+	_ driver.ExecerContext      = (*wrappedConn)(nil)
+	_ driver.QueryerContext     = (*wrappedConn)(nil)
+	_ driver.ConnPrepareContext = (*wrappedConn)(nil)
+	_ driver.ConnBeginTx        = (*wrappedConn)(nil)
+	_ driver.Pinger             = (*wrappedConn)(nil)
+	_ driver.SessionResetter    = (*wrappedConn)(nil)
+	_ driver.Validator          = (*wrappedConn)(nil)
+	_ driver.NamedValueChecker  = (*wrappedConn)(nil)
+	_ driver.StmtExecContext    = (*wrappedStmt)(nil)
+	_ driver.StmtQueryContext   = (*wrappedStmt)(nil)
+	_ driver.NamedValueChecker  = (*wrappedStmt)(nil)
+
+	_ driver.RowsNextResultSet              = (*wrappedRows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*wrappedRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*wrappedRows)(nil)
+	_ driver.RowsColumnTypeLength           = (*wrappedRows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*wrappedRows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*wrappedRows)(nil)
+)
+
+func (c *wrappedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *wrappedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, c.cfg.wrap(err, "prepare", query, start)
+	}
+
+	return &wrappedStmt{Stmt: stmt, conn: c.Conn, cfg: c.cfg, query: query}, nil
+}
+
+func (c *wrappedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *wrappedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+
+	var (
+		tx  driver.Tx
+		err error
+	)
+
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin() //nolint:staticcheck
+	}
+
+	if err != nil {
+		return nil, c.cfg.wrap(err, "begin", "", start)
+	}
+
+	return &wrappedTx{Tx: tx, cfg: c.cfg}, nil
+}
+
+func (c *wrappedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+
+	result, err := execer.ExecContext(ctx, query, args)
+
+	return result, c.cfg.wrap(err, "exec", query, start)
+}
+
+func (c *wrappedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, c.cfg.wrap(err, "query", query, start)
+	}
+
+	return &wrappedRows{Rows: rows, cfg: c.cfg, query: query, start: start}, nil
+}
+
+func (c *wrappedConn) Ping(ctx context.Context) error {
+	pinger, ok := c.Conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+
+	start := time.Now()
+
+	return c.cfg.wrap(pinger.Ping(ctx), "ping", "", start)
+}
+
+func (c *wrappedConn) ResetSession(ctx context.Context) error {
+	resetter, ok := c.Conn.(driver.SessionResetter)
+	if !ok {
+		return nil
+	}
+
+	// database/sql relies on driver.ErrBadConn, which survives wrapping through Is.
+	return c.cfg.wrap(resetter.ResetSession(ctx), "reset_session", "", time.Now())
+}
+
+func (c *wrappedConn) IsValid() bool {
+	validator, ok := c.Conn.(driver.Validator)
+
+	return !ok || validator.IsValid()
+}
+
+func (c *wrappedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+func (c *wrappedConn) Close() error {
+	return c.cfg.wrap(c.Conn.Close(), "close", "", time.Now())
+}
+
+func (s *wrappedStmt) Exec(args []driver.Value) (driver.Result, error) { //nolint:staticcheck
+	start := time.Now()
+
+	result, err := s.Stmt.Exec(args) //nolint:staticcheck
+
+	return result, s.cfg.wrap(err, "exec", s.query, start)
+}
+
+func (s *wrappedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.Exec(values)
+	}
+
+	start := time.Now()
+
+	result, err := execer.ExecContext(ctx, args)
+
+	return result, s.cfg.wrap(err, "exec", s.query, start)
+}
+
+func (s *wrappedStmt) Query(args []driver.Value) (driver.Rows, error) { //nolint:staticcheck
+	start := time.Now()
+
+	rows, err := s.Stmt.Query(args) //nolint:staticcheck
+	if err != nil {
+		return nil, s.cfg.wrap(err, "query", s.query, start)
+	}
+
+	return &wrappedRows{Rows: rows, cfg: s.cfg, query: s.query, start: start}, nil
+}
+
+func (s *wrappedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.Query(values)
+	}
+
+	start := time.Now()
+
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		return nil, s.cfg.wrap(err, "query", s.query, start)
+	}
+
+	return &wrappedRows{Rows: rows, cfg: s.cfg, query: s.query, start: start}, nil
+}
+
+// CheckNamedValue forwards to the checker of the statement, or else of the connection, as database/sql does
+// for unwrapped drivers; driver.ErrSkip then selects the default conversion.
+func (s *wrappedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	if checker, ok := s.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+func (s *wrappedStmt) Close() error {
+	return s.cfg.wrap(s.Stmt.Close(), "close_statement", s.query, time.Now())
+}
+
+func (t *wrappedTx) Commit() error {
+	start := time.Now()
+
+	return t.cfg.wrap(t.Tx.Commit(), "commit", "", start)
+}
+
+func (t *wrappedTx) Rollback() error {
+	start := time.Now()
+
+	return t.cfg.wrap(t.Tx.Rollback(), "rollback", "", start)
+}
+
+func (r *wrappedRows) Next(dest []driver.Value) error {
+	return r.cfg.wrap(r.Rows.Next(dest), "next", r.query, r.start)
+}
+
+func (r *wrappedRows) Close() error {
+	return r.cfg.wrap(r.Rows.Close(), "close_rows", r.query, r.start)
+}
+
+// The optional Rows interfaces are forwarded when the driver implements them; otherwise the methods return what
+// database/sql assumes for drivers lacking them.
+
+func (r *wrappedRows) HasNextResultSet() bool {
+	next, ok := r.Rows.(driver.RowsNextResultSet)
+
+	return ok && next.HasNextResultSet()
+}
+
+func (r *wrappedRows) NextResultSet() error {
+	next, ok := r.Rows.(driver.RowsNextResultSet)
+	if !ok {
+		return io.EOF
+	}
+
+	return r.cfg.wrap(next.NextResultSet(), "next_result_set", r.query, r.start)
+}
+
+func (r *wrappedRows) ColumnTypeScanType(index int) reflect.Type {
+	if prop, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return prop.ColumnTypeScanType(index)
+	}
+
+	return reflect.TypeFor[any]()
+}
+
+func (r *wrappedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if prop, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return prop.ColumnTypeDatabaseTypeName(index)
+	}
+
+	return ""
+}
+
+func (r *wrappedRows) ColumnTypeLength(index int) (int64, bool) {
+	if prop, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return prop.ColumnTypeLength(index)
+	}
+
+	return 0, false
+}
+
+func (r *wrappedRows) ColumnTypeNullable(index int) (bool, bool) {
+	if prop, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return prop.ColumnTypeNullable(index)
+	}
+
+	return false, false
+}
+
+func (r *wrappedRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if prop, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return prop.ColumnTypePrecisionScale(index)
+	}
+
+	return 0, 0, false
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, driver.ErrSkip
+		}
+
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
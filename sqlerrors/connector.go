@@ -0,0 +1,167 @@
+// Package sqlerrors provides a database/sql driver.Connector wrapper that intercepts every driver error
+// and wraps it with the executing query name, the call duration and an optional classification,
+// so database errors surface through github.com/ceearrashee/errors without touching repository code:
+//
+//	db := sql.OpenDB(sqlerrors.WrapConnector(connector))
+package sqlerrors
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FieldQueryName is the field holding the name of the failing query.
+	FieldQueryName = "db.query_name"
+	// FieldStatement is the field holding the full SQL of the failing query, when enabled.
+	FieldStatement = "db.statement"
+	// FieldDuration is the field holding the duration of the failing call.
+	FieldDuration = "db.duration"
+	// FieldOperation is the field holding the failing driver operation (query, exec, commit...).
+	FieldOperation = "db.operation"
+)
+
+type (
+	// Option customizes the wrapping performed by the connector.
+	Option func(*config)
+
+	config struct {
+		includeStatement bool
+		namer            func(query string) string
+		classifier       func(err error) error
+	}
+
+	connector struct {
+		driver.Connector
+
+		cfg *config
+	}
+)
+
+// queryNamePattern matches sqlc-style name annotations such as "-- name: GetUser :one" or "/* name: GetUser */".
+var queryNamePattern = regexp.MustCompile(`(?:--|/\*)\s*name:\s*(\w+)`) //nolint:gochecknoglobals
+
+// IncludeStatement records the full SQL of failing queries in the FieldStatement field.
+// It is off by default, since statements may be large or contain sensitive literals.
+//
+// Returns:
+//   - Option: the option enabling statement recording
+func IncludeStatement() Option {
+	return func(c *config) {
+		c.includeStatement = true
+	}
+}
+
+// QueryNamer replaces the function deriving a query name from its SQL.
+//
+// Parameters:
+//   - namer: the function returning the name of a query
+//
+// Returns:
+//   - Option: the option applying the namer
+func QueryNamer(namer func(query string) string) Option {
+	return func(c *config) {
+		c.namer = namer
+	}
+}
+
+// Classifier sets the function mapping driver errors (e.g., unique violations) onto predefined errors.
+//
+// Parameters:
+//   - classifier: returns the sentinel for a driver error, or nil to leave it unclassified
+//
+// Returns:
+//   - Option: the option applying the classifier
+func Classifier(classifier func(err error) error) Option {
+	return func(c *config) {
+		c.classifier = classifier
+	}
+}
+
+// WrapConnector wraps a driver.Connector so every error returned by its connections, statements,
+// transactions and rows is wrapped with the query name, duration and classification.
+//
+// Parameters:
+//   - c: the connector to wrap
+//   - opts: options customizing the wrapping
+//
+// Returns:
+//   - driver.Connector: the wrapping connector
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	cfg := &config{namer: DefaultQueryName}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &connector{Connector: c, cfg: cfg}
+}
+
+// DefaultQueryName derives a query name from a "name:" annotation comment if present,
+// or from the leading SQL keyword otherwise (e.g., "SELECT").
+//
+// Parameters:
+//   - query: the SQL of the query
+//
+// Returns:
+//   - string: the query name
+func DefaultQueryName(query string) string {
+	if match := queryNamePattern.FindStringSubmatch(query); match != nil {
+		return match[1]
+	}
+
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+
+	return "unknown"
+}
+
+// Connect opens a connection whose errors are wrapped.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	start := time.Now()
+
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, c.cfg.wrap(err, "connect", "", start)
+	}
+
+	return &wrappedConn{Conn: conn, cfg: c.cfg}, nil
+}
+
+// wrap wraps a driver error, leaving the sentinel values compared by identity in database/sql untouched.
+func (c *config) wrap(err error, operation, query string, start time.Time) error {
+	if err == nil || err == driver.ErrSkip || err == driver.ErrRemoveArgument || err == io.EOF { //nolint:errorlint
+		return err
+	}
+
+	fields := errors.Fields{
+		FieldOperation: operation,
+		FieldDuration:  time.Since(start).String(),
+	}
+
+	description := "db " + operation
+
+	if query != "" {
+		name := c.namer(query)
+		fields[FieldQueryName] = name
+		description += " " + name
+
+		if c.includeStatement {
+			fields[FieldStatement] = query
+		}
+	}
+
+	if c.classifier != nil {
+		if sentinel := c.classifier(err); sentinel != nil && !errors.Is(err, sentinel) {
+			err = errors.Join(sentinel, err)
+		}
+	}
+
+	return errors.Annotate(errors.WrapSkipping(err, 1, description), errors.WithFields(fields))
+}
@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"slices"
+)
+
+// TopN returns the n innermost frames of the stack.
+//
+// Parameters:
+//   - n: the number of frames to keep
+//
+// Returns:
+//   - Stack: a copy of at most n frames, starting with the most recent call
+func (s Stack) TopN(n int) Stack {
+	if n < 0 {
+		n = 0
+	}
+
+	return slices.Clone(s[:min(n, len(s))])
+}
+
+// Equal reports whether both stacks contain the same program counters in the same order.
+//
+// Parameters:
+//   - other: the stack to compare with
+//
+// Returns:
+//   - bool: true if the stacks are identical
+func (s Stack) Equal(other Stack) bool {
+	return slices.Equal(s, other)
+}
+
+// CommonSuffix returns the outermost frames shared by both stacks, such as the frames of a common caller.
+//
+// Parameters:
+//   - other: the stack to compare with
+//
+// Returns:
+//   - Stack: a copy of the shared outermost frames, empty if the stacks have none in common
+func (s Stack) CommonSuffix(other Stack) Stack {
+	n := s.commonSuffixLen(other)
+
+	return slices.Clone(s[len(s)-n:])
+}
+
+// Merge stitches two stacks together: the frames of s come first, followed by the frames of other.
+// Frames that s shares with other as a common suffix are kept only once, at the end of the result.
+//
+// Parameters:
+//   - other: the stack to append
+//
+// Returns:
+//   - Stack: the merged stack
+func (s Stack) Merge(other Stack) Stack {
+	n := s.commonSuffixLen(other)
+
+	merged := make(Stack, 0, len(s)-n+len(other))
+	merged = append(merged, s[:len(s)-n]...)
+
+	return append(merged, other...)
+}
+
+func (s Stack) commonSuffixLen(other Stack) int {
+	n := 0
+	for n < len(s) && n < len(other) && s[len(s)-1-n] == other[len(other)-1-n] {
+		n++
+	}
+
+	return n
+}
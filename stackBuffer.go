@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"runtime"
+	"slices"
+)
+
+// MaxCaptureDepth is the number of frames a StackBuffer holds.
+const MaxCaptureDepth = 64
+
+type (
+	// StackBuffer records a call stack into a fixed buffer without allocating, taking locks or running hooks,
+	// so crash handlers, signal-driven dumps and cgo callbacks can capture a stack without re-entrancy risks.
+	// Allocate it beforehand, capture in the sensitive context, and convert it with Stack once back in normal code:
+	//
+	//	var buf errors.StackBuffer // allocated before installing the handler
+	//	...
+	//	buf.Capture(0)        // in the handler
+	//	...
+	//	err = errors.AddCustomCallStack(cause, buf.Stack()) // later
+	StackBuffer struct {
+		pcs [MaxCaptureDepth]uintptr
+		n   int
+	}
+)
+
+// Capture records the call stack starting at the caller of Capture, replacing any stack recorded before.
+// Frames beyond MaxCaptureDepth are dropped. It does not allocate, and it bypasses SetCallersFunc.
+//
+// Parameters:
+//   - skip: the number of additional frames to skip
+func (b *StackBuffer) Capture(skip int) {
+	b.n = runtime.Callers(2+skip, b.pcs[:]) //nolint:mnd
+}
+
+// Len returns the number of frames recorded by the last Capture.
+//
+// Returns:
+//   - int: the number of recorded frames
+func (b *StackBuffer) Len() int {
+	return b.n
+}
+
+// Stack returns a copy of the recorded stack, to be resolved and attached to errors outside the sensitive context,
+// e.g. with AddCustomCallStack. Unlike Capture, it allocates.
+//
+// Returns:
+//   - *Stack: the recorded stack, or nil if nothing was captured
+func (b *StackBuffer) Stack() *Stack {
+	if b.n == 0 {
+		return nil
+	}
+
+	st := Stack(slices.Clone(b.pcs[:b.n]))
+
+	return &st
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// StackStyleGo renders frames like a Go panic: "pkg.Func(...)" followed by "\tfile:line".
+	StackStyleGo StackStyle = iota
+	// StackStyleJava renders frames like a Java stack trace: "\tat pkg.Func(file.go:line)".
+	StackStyleJava
+	// StackStyleJSON renders frames as a JSON array of objects with function, file, line and origin.
+	StackStyleJSON
+)
+
+type (
+	// StackStyle selects the format of a rendered stack, for log pipelines and error trackers parsing specific formats.
+	StackStyle int
+)
+
+// Render renders the stack in the given style, one frame per line except for StackStyleJSON.
+//
+// Parameters:
+//   - style: the rendering style
+//
+// Returns:
+//   - string: the rendered stack
+func (s Stack) Render(style StackStyle) string {
+	frames := s.Frames()
+
+	switch style {
+	case StackStyleJSON:
+		if frames == nil {
+			frames = []Frame{}
+		}
+
+		data, _ := json.Marshal(frames) //nolint:errcheck,errchkjson // frames always encode
+
+		return string(data)
+	case StackStyleJava:
+		lines := make([]string, 0, len(frames))
+		for _, frame := range frames {
+			lines = append(lines, fmt.Sprintf("\tat %s(%s:%d)", frame.Function, filepath.Base(frame.File), frame.Line))
+		}
+
+		return strings.Join(lines, "\n")
+	default:
+		lines := make([]string, 0, 2*len(frames)) //nolint:mnd
+		for _, frame := range frames {
+			lines = append(lines, frame.Function+"(...)", fmt.Sprintf("\t%s:%d", frame.File, frame.Line))
+		}
+
+		return strings.Join(lines, "\n")
+	}
+}
+
+// RenderStack renders the call stack of the innermost error of the chain carrying one in the given style.
+//
+// Parameters:
+//   - err: the error chain to search through
+//   - style: the rendering style
+//
+// Returns:
+//   - string: the rendered stack, or an empty string if no error of the chain carries a stack
+func RenderStack(err error, style StackStyle) string {
+	origin := FindOriginalErrorWithStack(err)
+	if origin == nil {
+		return ""
+	}
+
+	return origin.stack.Render(style)
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTrackedFingerprints caps the number of distinct fingerprints tracked by Observe.
+	maxTrackedFingerprints = 1000
+	// otherFingerprint collects occurrences beyond maxTrackedFingerprints.
+	otherFingerprint = "other"
+)
+
+type (
+	// Stats is a snapshot of the errors observed by the reporters.
+	Stats struct {
+		// Host is the name of the host the statistics were collected on.
+		Host string `json:"host,omitempty"`
+		// Total is the number of observed errors.
+		Total int64 `json:"total"`
+		// ByCode counts observed errors by their catalogue code ("" for unclassified errors).
+		ByCode map[ErrorCode]int64 `json:"by_code"`
+		// ByUpstream counts observed errors by the system recorded with WithUpstream ("" when none is recorded).
+		ByUpstream map[string]int64 `json:"by_upstream"`
+		// ByFingerprint counts observed errors by their AutoCode fingerprint.
+		ByFingerprint map[string]int64 `json:"by_fingerprint"`
+		// ByTenant counts observed errors by the tenant recorded with WithTenant ("" when none is recorded).
+		ByTenant map[string]int64 `json:"by_tenant"`
+		// ThrottledByTenant counts the reports degraded by AllowTenantReport, by tenant.
+		ThrottledByTenant map[string]int64 `json:"throttled_by_tenant,omitempty"`
+		// Exemplars holds the most recent occurrence of each fingerprint, most recent first.
+		Exemplars []Exemplar `json:"exemplars"`
+		// Bursting lists the fingerprints currently bursting, see IsBursting.
+		Bursting []string `json:"bursting,omitempty"`
+	}
+
+	// Exemplar is a recent occurrence of an error fingerprint.
+	Exemplar struct {
+		Fingerprint string    `json:"fingerprint"`
+		Code        ErrorCode `json:"code,omitempty"`
+		Message     string    `json:"message"`
+		Stack       []string  `json:"stack,omitempty"`
+		Time        time.Time `json:"time"`
+	}
+
+	statsRecorder struct {
+		mu            sync.Mutex
+		total         int64
+		byCode        map[ErrorCode]int64
+		byUpstream    map[string]int64
+		byFingerprint map[string]int64
+		byTenant      map[string]int64
+		exemplars     map[string]Exemplar
+	}
+)
+
+//nolint:gochecknoglobals
+var stats = &statsRecorder{
+	byCode:        make(map[ErrorCode]int64),
+	byUpstream:    make(map[string]int64),
+	byFingerprint: make(map[string]int64),
+	byTenant:      make(map[string]int64),
+	exemplars:     make(map[string]Exemplar),
+}
+
+// Observe records an occurrence of err in the live statistics, remembers its site (see IsKnownSite)
+// and feeds the burst detector (see IsBursting).
+// Reporters call it for every reported error.
+//
+// Parameters:
+//   - err: the reported error; nil is ignored
+func Observe(err error) {
+	if err == nil {
+		return
+	}
+
+	exemplar := Exemplar{
+		Fingerprint: AutoCode(err),
+		Code:        CodeOf(err),
+		Message:     ScrubMessage(err.Error()),
+		Time:        now(),
+	}
+
+	if origin := FindOriginalErrorWithStack(err); origin != nil {
+		exemplar.Stack = origin.GetCallStack()
+	}
+
+	rememberSite(exemplar.Fingerprint)
+	bursts.observe(exemplar.Fingerprint, exemplar.Time)
+
+	upstream, _ := UpstreamOf(err)
+	tenant := TenantOf(err)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.total++
+	stats.byCode[exemplar.Code]++
+	stats.byUpstream[upstream.System]++
+
+	if _, tracked := stats.byTenant[tenant]; !tracked && len(stats.byTenant) >= maxTrackedTenants {
+		tenant = otherFingerprint
+	}
+
+	stats.byTenant[tenant]++
+
+	if _, tracked := stats.byFingerprint[exemplar.Fingerprint]; !tracked && len(stats.byFingerprint) >= maxTrackedFingerprints {
+		stats.byFingerprint[otherFingerprint]++
+
+		return
+	}
+
+	stats.byFingerprint[exemplar.Fingerprint]++
+	stats.exemplars[exemplar.Fingerprint] = exemplar
+}
+
+// Snapshot returns a copy of the live statistics of observed errors.
+//
+// Returns:
+//   - Stats: the current statistics
+func Snapshot() Stats {
+	host := hostname()
+	bursting := bursts.snapshot(now())
+	throttled := tenants.snapshot()
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	snapshot := Stats{
+		Host:              host,
+		Total:             stats.total,
+		ByCode:            maps.Clone(stats.byCode),
+		ByUpstream:        maps.Clone(stats.byUpstream),
+		ByFingerprint:     maps.Clone(stats.byFingerprint),
+		ByTenant:          maps.Clone(stats.byTenant),
+		ThrottledByTenant: throttled,
+		Exemplars:         make([]Exemplar, 0, len(stats.exemplars)),
+		Bursting:          bursting,
+	}
+
+	for _, exemplar := range stats.exemplars {
+		snapshot.Exemplars = append(snapshot.Exemplars, exemplar)
+	}
+
+	sort.Slice(snapshot.Exemplars, func(i, j int) bool {
+		return snapshot.Exemplars[i].Time.After(snapshot.Exemplars[j].Time)
+	})
+
+	return snapshot
+}
+
+// ResetStats clears the live statistics of observed errors, the rates tracked by the burst detector and the
+// quotas consumed by the tenants.
+func ResetStats() {
+	bursts.reset()
+	tenants.reset()
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.total = 0
+	clear(stats.byCode)
+	clear(stats.byUpstream)
+	clear(stats.byFingerprint)
+	clear(stats.byTenant)
+	clear(stats.exemplars)
+}
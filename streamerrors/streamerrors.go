@@ -0,0 +1,241 @@
+// Package streamerrors serializes errors for streaming APIs — JSON error frames, WebSocket close reasons and
+// Server-Sent Events "error" events — carrying the error code, a safe message and the reference code instead of
+// the raw err.Error() string, and parses them back into classified errors on the client side.
+package streamerrors
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FrameType is the type of JSON error frames.
+	FrameType = "error"
+
+	// CloseUnsupportedData is the WebSocket close code for errors caused by the client request.
+	CloseUnsupportedData = 1003
+	// ClosePolicyViolation is the WebSocket close code for authentication and authorization errors.
+	ClosePolicyViolation = 1008
+	// CloseInternalServerErr is the WebSocket close code for server errors.
+	CloseInternalServerErr = 1011
+	// CloseTryAgainLater is the WebSocket close code for retryable errors.
+	CloseTryAgainLater = 1013
+
+	// maxCloseReasonLength is the maximum length in bytes of a WebSocket close reason (RFC 6455, section 5.5).
+	maxCloseReasonLength = 123
+	// codeSeparator separates the code from the message in close reasons.
+	codeSeparator = ": "
+)
+
+// Frame is the JSON representation of an error sent over a stream.
+type Frame struct {
+	// Type is always FrameType, letting clients tell error frames from data frames.
+	Type string `json:"type"`
+	// Code is the registered code of the error, or the code of ErrInternalServerError if unclassified.
+	Code errors.ErrorCode `json:"code,omitempty"`
+	// Message is the safe, client-facing message of the error.
+	Message string `json:"message"`
+	// ReferenceCode is the reference code used to find the error in logs.
+	ReferenceCode string `json:"reference_code,omitempty"`
+	// Retryable reports whether the client may retry.
+	Retryable bool `json:"retryable,omitempty"`
+}
+
+// NewFrame builds the error frame of err. The message is the one of the registered definition matching the error,
+// so internal details never reach clients; unclassified errors get the message of ErrInternalServerError.
+//
+// Parameters:
+//   - err: the error to describe
+//
+// Returns:
+//   - Frame: the error frame
+func NewFrame(err error) Frame {
+	def, ok := errors.DefinitionOf(err)
+	if !ok {
+		def, _ = errors.DefinitionOf(errors.ErrInternalServerError)
+	}
+
+	return Frame{
+		Type:          FrameType,
+		Code:          def.Code,
+		Message:       def.Message,
+		ReferenceCode: errors.AutoCode(err),
+		Retryable:     def.Retryable,
+	}
+}
+
+// Err converts the frame back into an error classified with the registered definition of its code.
+//
+// Returns:
+//   - error: the classified error
+func (f Frame) Err() error {
+	var err error = errors.New(f.Message)
+
+	if def, ok := errors.DefinitionByCode(f.Code); ok && def.Err != nil {
+		err = fmt.Errorf("%w: %w", def.Err, err)
+	}
+
+	if f.ReferenceCode == "" {
+		return err
+	}
+
+	return errors.Annotate(err, errors.WithField(errors.FieldReferenceCode, f.ReferenceCode))
+}
+
+// MarshalFrame serializes the error frame of err into JSON, ready to be sent as a WebSocket text message.
+//
+// Parameters:
+//   - err: the error to serialize
+//
+// Returns:
+//   - []byte: the JSON frame
+//   - error: an error if the frame cannot be serialized
+func MarshalFrame(err error) ([]byte, error) {
+	data, marshalErr := json.Marshal(NewFrame(err))
+	if marshalErr != nil {
+		return nil, errors.Wrap(marshalErr, "marshal error frame")
+	}
+
+	return data, nil
+}
+
+// ParseFrame parses a message received from a stream, reporting whether it is an error frame.
+//
+// Parameters:
+//   - data: the received message
+//
+// Returns:
+//   - error: the classified error described by the frame, or nil if the message is not an error frame
+//   - bool: true if the message is an error frame
+func ParseFrame(data []byte) (error, bool) { //nolint:revive
+	var frame Frame
+	if err := json.Unmarshal(data, &frame); err != nil || frame.Type != FrameType {
+		return nil, false
+	}
+
+	return frame.Err(), true
+}
+
+// CloseReason returns the WebSocket close code and reason describing err. The reason holds the error code and
+// the safe message ("not_found: entity not found"), truncated to the 123 bytes allowed by the protocol.
+//
+// Parameters:
+//   - err: the error closing the connection
+//
+// Returns:
+//   - int: the close code
+//   - string: the close reason
+func CloseReason(err error) (int, string) {
+	frame := NewFrame(err)
+
+	reason := frame.Message
+	if frame.Code != "" {
+		reason = string(frame.Code) + codeSeparator + reason
+	}
+
+	return closeCode(err, frame), truncate(reason, maxCloseReasonLength)
+}
+
+// ParseCloseReason converts a WebSocket close reason produced by CloseReason back into a classified error.
+//
+// Parameters:
+//   - reason: the received close reason
+//
+// Returns:
+//   - error: the classified error
+func ParseCloseReason(reason string) error {
+	code, message, found := strings.Cut(reason, codeSeparator)
+	if !found {
+		return Frame{Message: reason}.Err()
+	}
+
+	return Frame{Code: errors.ErrorCode(code), Message: message}.Err()
+}
+
+// WriteSSE writes err as a Server-Sent Events "error" event whose data is the JSON error frame.
+//
+// Parameters:
+//   - w: the event stream to write to
+//   - err: the error to send
+//
+// Returns:
+//   - error: an error if the event cannot be written
+func WriteSSE(w io.Writer, err error) error {
+	data, marshalErr := MarshalFrame(err)
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	if _, writeErr := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", FrameType, data); writeErr != nil {
+		return errors.Wrap(writeErr, "write error event")
+	}
+
+	return nil
+}
+
+// ParseSSE parses a Server-Sent Event, reporting whether it is an "error" event written by WriteSSE.
+//
+// Parameters:
+//   - event: the raw event, i.e. its field lines without the terminating blank line
+//
+// Returns:
+//   - error: the classified error described by the event, or nil if the event is not an error event
+//   - bool: true if the event is an error event
+func ParseSSE(event string) (error, bool) { //nolint:revive
+	var (
+		name string
+		data []string
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(event))
+	for scanner.Scan() {
+		field, value, _ := strings.Cut(scanner.Text(), ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if name != FrameType {
+		return nil, false
+	}
+
+	return ParseFrame([]byte(strings.Join(data, "\n")))
+}
+
+func closeCode(err error, frame Frame) int {
+	switch {
+	case frame.Retryable:
+		return CloseTryAgainLater
+	case errors.Is(err, errors.ErrUnauthorized), errors.Is(err, errors.ErrRegistrationRequired),
+		errors.Is(err, errors.ErrForbiddenAction):
+		return ClosePolicyViolation
+	case errors.HTTPStatusOf(err) < 500:
+		return CloseUnsupportedData
+	default:
+		return CloseInternalServerErr
+	}
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
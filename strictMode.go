@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"sync/atomic"
+)
+
+// StrictMode defines how programmer errors in this package's API usage are surfaced.
+type StrictMode int32
+
+const (
+	// StrictOff keeps the historical silent behavior (the default).
+	StrictOff StrictMode = iota
+	// StrictLog logs every detected misuse with its call site.
+	StrictLog
+	// StrictPanic panics on every detected misuse; intended for tests.
+	StrictPanic
+)
+
+var strictMode atomic.Int32 //nolint:gochecknoglobals
+
+// SetStrictMode sets how misuse of the package's API is reported, such as wrapping with a nil sentinel error.
+// Enabling StrictPanic in tests turns these silent failures into loud ones.
+//
+// Parameters:
+//   - mode: the strict mode to apply
+func SetStrictMode(mode StrictMode) {
+	strictMode.Store(int32(mode))
+}
+
+// reportMisuse surfaces an API misuse according to the configured strict mode.
+// The reported call site is the caller of the public function that detected the misuse.
+func reportMisuse(format string, args ...any) {
+	mode := StrictMode(strictMode.Load())
+	if mode == StrictOff {
+		return
+	}
+
+	surface(mode, fmt.Sprintf(format, args...))
+}
+
+// reportViolation surfaces a violation explicitly checked for by the caller: it is logged even
+// when strict mode is off, and panics under StrictPanic.
+func reportViolation(format string, args ...any) {
+	mode := StrictMode(strictMode.Load())
+	if mode == StrictOff {
+		mode = StrictLog
+	}
+
+	surface(mode, fmt.Sprintf(format, args...))
+}
+
+func surface(mode StrictMode, message string) {
+	const callerDepth = 3
+
+	if _, file, line, ok := runtime.Caller(callerDepth); ok {
+		message = fmt.Sprintf("%s (at %s:%d)", message, file, line)
+	}
+
+	if mode == StrictPanic {
+		panic("errors: misuse: " + message)
+	}
+
+	log.Printf("errors: misuse: %s", message)
+}
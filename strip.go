@@ -0,0 +1,62 @@
+package errors
+
+import (
+	stdErrors "errors"
+	"fmt"
+)
+
+// Strip converts err into a plain standard library error chain with the same messages, for boundaries where
+// the types of this package must not leak, such as public SDKs and plugin interfaces. Each *Error level becomes
+// a fmt.Errorf wrapper (dropping its stack and metadata) and aggregates become errors.Join values, including
+// those found below wrappers of other packages, which are rebuilt with fmt.Errorf or errors.Join around them.
+// Registered sentinels, such as ErrNotFound, are the only *Error values kept, so Is keeps matching them; errors
+// of other packages with no *Error below them are kept unchanged so As keeps matching their types.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - error: the plain error chain, or nil if err is nil
+func Strip(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	separator := currentRenderConfig().separator
+	walker := &chainWalker{isSentinel: isRegisteredSentinel(), join: stdErrors.Join}
+	walker.level = func(e *Error, _ func(error) (error, bool)) error {
+		switch {
+		case e.error == nil:
+			return stdErrors.New(e.Description) //nolint:err113
+		case e.Description == "":
+			return walker.rebuild(e.error)
+		default:
+			return fmt.Errorf("%s%s%w", e.Description, separator, walker.rebuild(e.error))
+		}
+	}
+
+	return walker.rebuild(err)
+}
+
+// isRegisteredSentinel returns a predicate reporting whether an error is the sentinel of a registered definition.
+func isRegisteredSentinel() func(*Error) bool {
+	registryMu.RLock()
+	sentinels := make([]error, 0, len(definitions))
+
+	for _, def := range definitions {
+		if def.Err != nil {
+			sentinels = append(sentinels, def.Err)
+		}
+	}
+	registryMu.RUnlock()
+
+	return func(err *Error) bool {
+		for _, sentinel := range sentinels {
+			if sentinel == error(err) { //nolint:errorlint
+				return true
+			}
+		}
+
+		return false
+	}
+}
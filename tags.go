@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"slices"
+)
+
+// WithTags attaches flat string tags to the error, such as "team:payments" or "oncall:infra",
+// which reporters emit to route alerts. Tags are distinct from the key-value Fields.
+//
+// Parameters:
+//   - tags: the tags to add; duplicates are ignored
+//
+// Returns:
+//   - Option: the option adding the tags
+func WithTags(tags ...string) Option {
+	return func(e *Error) {
+		for _, tag := range tags {
+			if tag != "" && !slices.Contains(e.tags, tag) {
+				e.tags = append(e.tags, tag)
+			}
+		}
+	}
+}
+
+// TagsOf collects the tags attached anywhere in the chain, without duplicates.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - []string: the tags, outermost first, or nil if there are none
+func TagsOf(err error) []string {
+	var tags []string
+
+	for e := range Chain(err) {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
+		if !ok {
+			continue
+		}
+
+		for _, tag := range frameworkErr.tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// NewTpl creates a new Error whose description is rendered from a template with named placeholders.
+// Placeholders use the {name} syntax and are filled from the given fields; unknown placeholders are kept as-is.
+// The raw template is preserved so monitoring tools can group occurrences regardless of the values.
+//
+// Parameters:
+//   - template: the description template, e.g. "order {order_id} failed for user {user_id}"
+//   - fields: the values used to fill the placeholders, also attached to the error as structured fields
+//
+// Returns:
+//   - error: an Error with the rendered description, template and fields
+func NewTpl(template string, fields Fields) error {
+	return track(&Error{
+		Description: limitDescription(renderTemplate(template, fields)),
+		template:    template,
+		fields:      maps.Clone(fields),
+	})
+}
+
+// WrapTpl wraps an existing error with a templated description, its fields and a stack trace.
+//
+// Parameters:
+//   - err: the original error to wrap
+//   - template: the description template with {name} placeholders
+//   - fields: the values used to fill the placeholders, also attached to the error as structured fields
+//
+// Returns:
+//   - error: a wrapped error with the rendered description, or nil if the input error is nil
+func WrapTpl(err error, template string, fields Fields) error {
+	if err == nil {
+		recordNilWrap("WrapTpl", template, 0)
+
+		return nil
+	}
+
+	return track(&Error{
+		Description: limitDescription(renderTemplate(template, fields)),
+		template:    template,
+		fields:      maps.Clone(fields),
+		stack:       stackFor(err, 0),
+		error:       err,
+	})
+}
+
+// Template returns the raw description template the error was created from, if any.
+//
+// Returns:
+//   - string: the template, or an empty string if the error has none
+func (e *Error) Template() string {
+	return e.template
+}
+
+// TemplateOf returns the raw description template of the first templated error in the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - string: the template, or an empty string if no templated error is found
+func TemplateOf(err error) string {
+	found := First(err, func(e error) bool {
+		frameworkErr, ok := e.(*Error) //nolint:errorlint
+
+		return ok && frameworkErr.template != ""
+	})
+
+	if frameworkErr, ok := found.(*Error); ok { //nolint:errorlint
+		return frameworkErr.template
+	}
+
+	return ""
+}
+
+func renderTemplate(template string, fields Fields) string {
+	var builder strings.Builder
+
+	builder.Grow(len(template))
+
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			builder.WriteString(rest)
+
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			builder.WriteString(rest)
+
+			break
+		}
+
+		end += start
+		builder.WriteString(rest[:start])
+
+		if value, ok := fields[rest[start+1:end]]; ok {
+			builder.WriteString(fmt.Sprint(value))
+		} else {
+			builder.WriteString(rest[start : end+1])
+		}
+
+		rest = rest[end+1:]
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,174 @@
+// Package temporalerrors converts errors to and from Temporal (and Cadence) workflow errors, so workflows keep the
+// classification and metadata of activity failures across activity boundaries.
+//
+// The package does not depend on the Temporal or Cadence SDKs. Activities describe their failure with ApplicationOf
+// and build the SDK error from it:
+//
+//	app := temporalerrors.ApplicationOf(err)
+//	return temporal.NewApplicationErrorWithOptions(app.Message, app.Type, temporal.ApplicationErrorOptions{
+//		NonRetryable: app.NonRetryable,
+//		Cause:        err,
+//		Details:      []any{app.Details},
+//	})
+//
+// Workflows convert the *temporal.ActivityError returned by the activity future back:
+//
+//	if err := workflow.ExecuteActivity(ctx, Charge, order).Get(ctx, nil); err != nil {
+//		return temporalerrors.FromApplicationError(err) // errors.Is(err, errors.ErrNotFound), FieldsOf, …
+//	}
+//
+// With Cadence, pass app.Type as the reason of cadence.NewCustomError and list the non-retryable types in
+// RetryPolicy.NonRetriableErrorReasons.
+package temporalerrors
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FieldType is the field holding the type of the received application error (its reason with Cadence).
+	FieldType = "temporal.type"
+	// FieldActivityID is the field holding the ID of the activity the error was received from.
+	FieldActivityID = "temporal.activity_id"
+	// FieldReferenceCode is the field holding the reference code the error was reported with by the activity.
+	FieldReferenceCode = errors.FieldReferenceCode
+)
+
+type (
+	// Application describes an error as a Temporal ApplicationError.
+	Application struct {
+		// Message is the scrubbed message of the error.
+		Message string
+		// Type is the registered code of the error, or the code of errors.ErrInternalServerError if unclassified,
+		// so retry policies can list non-retryable types.
+		Type string
+		// NonRetryable reports whether the activity must not be retried: classified errors are non-retryable unless
+		// their definition is retryable (see errors.IsRetryable); unclassified errors are left to the retry policy.
+		NonRetryable bool
+		// Details is the payload to pass as the details of the application error.
+		Details Details
+	}
+
+	// Details is the metadata of an error carried as the details of an application error.
+	Details struct {
+		Code          errors.ErrorCode `json:"code,omitempty"`
+		ReferenceCode string           `json:"reference_code,omitempty"`
+		Fields        errors.Fields    `json:"fields,omitempty"`
+	}
+
+	// applicationError is implemented by *temporal.ApplicationError.
+	applicationError interface {
+		error
+		Type() string
+		HasDetails() bool
+		Details(d ...any) error
+	}
+
+	// customError is implemented by *cadence.CustomError.
+	customError interface {
+		error
+		Reason() string
+		HasDetails() bool
+		Details(d ...any) error
+	}
+
+	// activityError is implemented by *temporal.ActivityError and *cadence.ActivityError wrappers exposing the ID.
+	activityError interface {
+		error
+		ActivityID() string
+	}
+)
+
+// ApplicationOf describes err as a Temporal application error.
+//
+// Parameters:
+//   - err: the error returned by the activity
+//
+// Returns:
+//   - Application: the description of the error, zero if err is nil
+func ApplicationOf(err error) Application {
+	if err == nil {
+		return Application{}
+	}
+
+	def, classified := errors.DefinitionOf(err)
+	if !classified {
+		def, _ = errors.DefinitionOf(errors.ErrInternalServerError)
+	}
+
+	return Application{
+		Message:      errors.ScrubMessage(err.Error()),
+		Type:         string(def.Code),
+		NonRetryable: classified && !def.Retryable,
+		Details: Details{
+			Code:          def.Code,
+			ReferenceCode: errors.AutoCode(err),
+			Fields:        errors.ScrubFields(errors.FieldsOf(err)),
+		},
+	}
+}
+
+// FromApplicationError converts an error received from an activity or child workflow — a Temporal ApplicationError,
+// possibly wrapped in an ActivityError, or a Cadence CustomError — into an error classified with the predefined error
+// registered for its type, carrying the fields sent in its details. The received error stays in the chain, so As
+// keeps matching the SDK types.
+//
+// Parameters:
+//   - err: the error received by the workflow
+//
+// Returns:
+//   - error: the classified error with a call stack, err itself if it holds no application error, or nil if err is nil
+func FromApplicationError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		errType string
+		details Details
+	)
+
+	if app, ok := errors.AsType[applicationError](err); ok {
+		errType = app.Type()
+		decodeDetails(app.HasDetails(), app.Details, &details)
+	} else if custom, ok := errors.AsType[customError](err); ok {
+		errType = custom.Reason()
+		decodeDetails(custom.HasDetails(), custom.Details, &details)
+	} else {
+		return err
+	}
+
+	fields := make(errors.Fields, len(details.Fields)+3) //nolint:mnd
+	maps.Copy(fields, details.Fields)
+
+	fields[FieldType] = errType
+
+	if details.ReferenceCode != "" {
+		fields[FieldReferenceCode] = details.ReferenceCode
+	}
+
+	if activity, ok := errors.AsType[activityError](err); ok {
+		fields[FieldActivityID] = activity.ActivityID()
+	}
+
+	classified := err
+	if def, ok := errors.DefinitionByCode(errors.ErrorCode(errType)); ok && def.Err != nil {
+		classified = fmt.Errorf("%w: %w", def.Err, err)
+	}
+
+	return errors.Annotate(errors.WrapSkipping(classified, 1, ""), errors.WithFields(fields))
+}
+
+// decodeDetails decodes the first payload of the details into out, leaving it empty for payloads of other shapes.
+func decodeDetails(hasDetails bool, decode func(d ...any) error, out *Details) {
+	if !hasDetails {
+		return
+	}
+
+	if err := decode(out); err != nil {
+		*out = Details{}
+	}
+}
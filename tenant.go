@@ -0,0 +1,185 @@
+package errors
+
+import (
+	"maps"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxTrackedTenants caps the number of tenants whose quota is tracked; the others share a single quota.
+const maxTrackedTenants = 1000
+
+type (
+	// TenantQuota limits the full reports of a single tenant, so one tenant's error storm cannot exhaust the
+	// reporting budget shared with the other tenants. Reports beyond the quota are degraded to compact reports.
+	TenantQuota struct {
+		// Rate is the number of full reports per second the tenant may send; 0 sets no limit.
+		Rate float64
+		// Burst is the number of full reports the tenant may send at once before Rate applies; at least 1.
+		Burst int
+		// SampleRate is the fraction of the reports within the quota sent in full, in (0, 1]; 0 sends them all.
+		SampleRate float64
+	}
+
+	// TenantPolicy assigns the quota of each tenant.
+	TenantPolicy struct {
+		// Tenants maps tenant identifiers to their quota.
+		Tenants map[string]TenantQuota
+		// Default is the quota of the tenants missing from Tenants.
+		Default TenantQuota
+	}
+
+	// tenantBucket is the token bucket enforcing the rate of a tenant.
+	tenantBucket struct {
+		tokens float64
+		last   time.Time
+	}
+
+	tenantLimiter struct {
+		mu        sync.Mutex
+		buckets   map[string]*tenantBucket
+		throttled map[string]int64
+	}
+)
+
+var (
+	tenantPolicy atomic.Pointer[TenantPolicy]                                                                 //nolint:gochecknoglobals
+	tenants      = &tenantLimiter{buckets: make(map[string]*tenantBucket), throttled: make(map[string]int64)} //nolint:gochecknoglobals
+)
+
+// DefaultTenantPolicy returns the policy used until SetTenantPolicy is called, which sets no quota.
+//
+// Returns:
+//   - TenantPolicy: the default policy
+func DefaultTenantPolicy() TenantPolicy {
+	return TenantPolicy{}
+}
+
+// SetTenantPolicy replaces the policy used by AllowTenantReport. The quotas consumed so far are kept.
+//
+// Parameters:
+//   - policy: the policy to apply
+func SetTenantPolicy(policy TenantPolicy) {
+	tenantPolicy.Store(&policy)
+}
+
+// TenantOf returns the tenant recorded with WithTenant.
+//
+// Parameters:
+//   - err: the error to inspect
+//
+// Returns:
+//   - string: the tenant, or an empty string if none is recorded
+func TenantOf(err error) string {
+	tenant, _ := FieldsOf(err)[FieldTenant].(string)
+
+	return tenant
+}
+
+// AllowTenantReport consumes the quota of the tenant of err (see WithTenant) and reports whether the error may be
+// reported in full; reporters send a compact report otherwise. Errors without a tenant are always allowed.
+//
+// Parameters:
+//   - err: the reported error
+//
+// Returns:
+//   - bool: true if the report is within the quota of its tenant, false if it is throttled or sampled out
+func AllowTenantReport(err error) bool {
+	tenant := TenantOf(err)
+	if tenant == "" {
+		return true
+	}
+
+	quota := currentTenantPolicy().quotaOf(tenant)
+
+	allowed := tenants.allow(tenant, quota, now())
+	if allowed && quota.SampleRate > 0 && quota.SampleRate < 1 {
+		allowed = rand.Float64() < quota.SampleRate //nolint:gosec
+	}
+
+	if !allowed {
+		tenants.throttle(tenant)
+	}
+
+	return allowed
+}
+
+func currentTenantPolicy() TenantPolicy {
+	if policy := tenantPolicy.Load(); policy != nil {
+		return *policy
+	}
+
+	return DefaultTenantPolicy()
+}
+
+func (p TenantPolicy) quotaOf(tenant string) TenantQuota {
+	if quota, ok := p.Tenants[tenant]; ok {
+		return quota
+	}
+
+	return p.Default
+}
+
+// allow takes a token from the bucket of the tenant at t.
+func (l *tenantLimiter) allow(tenant string, quota TenantQuota, t time.Time) bool {
+	if quota.Rate <= 0 {
+		return true
+	}
+
+	burst := float64(max(quota.Burst, 1))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[tenant]
+	if !ok {
+		if len(l.buckets) >= maxTrackedTenants {
+			tenant = otherFingerprint
+			bucket, ok = l.buckets[tenant]
+		}
+
+		if !ok {
+			bucket = &tenantBucket{tokens: burst, last: t}
+			l.buckets[tenant] = bucket
+		}
+	}
+
+	bucket.tokens = min(burst, bucket.tokens+t.Sub(bucket.last).Seconds()*quota.Rate)
+	bucket.last = t
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+func (l *tenantLimiter) throttle(tenant string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, tracked := l.throttled[tenant]; !tracked && len(l.throttled) >= maxTrackedTenants {
+		tenant = otherFingerprint
+	}
+
+	l.throttled[tenant]++
+}
+
+func (l *tenantLimiter) snapshot() map[string]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return maps.Clone(l.throttled)
+}
+
+func (l *tenantLimiter) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	clear(l.buckets)
+	clear(l.throttled)
+}
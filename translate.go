@@ -0,0 +1,62 @@
+package errors
+
+// TranslatedFromCause is the name of the cause holding the original error of a translation.
+const TranslatedFromCause = "translated_from"
+
+type (
+	// Translation maps errors of one domain onto an error of another domain.
+	Translation struct {
+		// From is the source sentinel, matched with Is; optional if Code is set.
+		From error
+		// Code is the source catalogue code, matched with CodeOf; optional if From is set.
+		Code ErrorCode
+		// To is the error of the target domain.
+		To error
+	}
+
+	// TranslationTable is an ordered list of translations; the first matching entry wins.
+	TranslationTable []Translation
+)
+
+// Translate maps err onto the target domain at a service boundary using the first matching translation,
+// e.g. repository errors onto API errors, making layering rules explicit and testable.
+// The translated error matches the target error with Is but not the source one; the original error
+// is preserved as the TranslatedFromCause named cause, so it is still serialized and reported.
+//
+// Parameters:
+//   - err: the error to translate
+//   - table: the translations to apply
+//
+// Returns:
+//   - error: the translated error, err unchanged if no translation matches, or nil if err is nil
+func Translate(err error, table TranslationTable) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, translation := range table {
+		if !translation.matches(err) {
+			continue
+		}
+
+		return track(&Error{
+			stack:  stackFor(translation.To, 0),
+			error:  translation.To,
+			causes: map[string]error{TranslatedFromCause: err},
+		})
+	}
+
+	return err
+}
+
+func (t Translation) matches(err error) bool {
+	if t.To == nil {
+		return false
+	}
+
+	if t.From != nil && Is(err, t.From) {
+		return true
+	}
+
+	return t.Code != "" && CodeOf(err) == t.Code
+}
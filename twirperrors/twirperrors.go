@@ -0,0 +1,306 @@
+// Package twirperrors maps the predefined error taxonomy to Twirp error codes and meta and back,
+// for services exposing Twirp alongside gRPC and REST.
+//
+// The package does not depend on github.com/twitchtv/twirp, so it plugs into any Twirp version. The tradeoff is
+// that ToTwirp returns an *Error with the methods of twirp.Error, except that Code returns a plain string rather
+// than a twirp.ErrorCode, so it converts to a twirp.Error with one call:
+//
+//	e := twirperrors.ToTwirp(err)
+//	twerr := twirp.NewError(twirp.ErrorCode(e.Code()), e.Msg())
+//	for key, value := range e.MetaMap() {
+//		twerr = twerr.WithMeta(key, value)
+//	}
+//
+// In the other direction, FromTwirpError accepts twirp.Error values directly:
+//
+//	err := twirperrors.FromTwirpError(twerr)
+package twirperrors
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/ceearrashee/errors"
+)
+
+// Twirp error codes, as defined by the Twirp protocol specification.
+const (
+	Canceled           = "canceled"
+	Unknown            = "unknown"
+	InvalidArgument    = "invalid_argument"
+	Malformed          = "malformed"
+	DeadlineExceeded   = "deadline_exceeded"
+	NotFound           = "not_found"
+	BadRoute           = "bad_route"
+	AlreadyExists      = "already_exists"
+	PermissionDenied   = "permission_denied"
+	Unauthenticated    = "unauthenticated"
+	ResourceExhausted  = "resource_exhausted"
+	FailedPrecondition = "failed_precondition"
+	Aborted            = "aborted"
+	OutOfRange         = "out_of_range"
+	Unimplemented      = "unimplemented"
+	Internal           = "internal"
+	Unavailable        = "unavailable"
+	DataLoss           = "data_loss"
+)
+
+const (
+	// MetaCode is the meta key holding the registered error code.
+	MetaCode = "code"
+	// MetaReferenceCode is the meta key holding the reference code used to find the error in logs.
+	MetaReferenceCode = errors.FieldReferenceCode
+	// metaFieldPrefix prefixes the meta keys holding the fields of the error.
+	metaFieldPrefix = "field."
+)
+
+type (
+	// Error is a Twirp error built by ToTwirp, with the methods of twirp.Error; Code returns the string value of
+	// the twirp.ErrorCode. It unwraps into the converted error.
+	Error struct {
+		err  error
+		code string
+		msg  string
+		meta map[string]string
+	}
+
+	// twirpError is implemented by twirp.Error values, whose Code method returns a twirp.ErrorCode.
+	twirpError interface {
+		error
+		Msg() string
+		MetaMap() map[string]string
+	}
+)
+
+// CodeOf returns the Twirp error code of the gRPC code of the registered definition of err (see errors.GRPCCodeOf),
+// standard library errors such as context.Canceled being classified first (see errors.Classify), so the codes
+// follow errors.RegisterGRPCCodeMapping; unclassified errors map to Internal.
+//
+// Parameters:
+//   - err: the error to classify
+//
+// Returns:
+//   - string: the Twirp error code, or an empty string if err is nil
+func CodeOf(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	def, ok := errors.DefinitionOf(errors.Classify(err))
+	if !ok {
+		return Internal
+	}
+
+	name := errors.GRPCCodeOf(def)
+	if name == "" {
+		return Internal
+	}
+
+	return twirpCode(name)
+}
+
+// MetaOf returns the Twirp meta describing the error: its registered code, reference code and fields,
+// the latter under "field."-prefixed keys since Twirp meta values are strings.
+//
+// Parameters:
+//   - err: the error to describe
+//
+// Returns:
+//   - map[string]string: the meta entries, or nil if err is nil
+func MetaOf(err error) map[string]string {
+	if err == nil {
+		return nil
+	}
+
+	meta := map[string]string{MetaReferenceCode: errors.AutoCode(err)}
+
+	if code := errors.CodeOf(err); code != "" {
+		meta[MetaCode] = string(code)
+	}
+
+	for key, value := range errors.ScrubFields(errors.FieldsOf(err)) {
+		meta[metaFieldPrefix+key] = fmt.Sprint(value)
+	}
+
+	return meta
+}
+
+// FromTwirp converts a Twirp error received by a client into an error classified with the sentinel the matching
+// gRPC code maps onto (see errors.SentinelForGRPCCode), keeping the Twirp meta as fields. The Twirp-only codes
+// Malformed and BadRoute are classified as InvalidArgument and NotFound.
+//
+// Parameters:
+//   - code: the Twirp error code, i.e. string(twerr.Code())
+//   - msg: the Twirp error message, i.e. twerr.Msg()
+//   - meta: the Twirp error meta, i.e. twerr.MetaMap()
+//
+// Returns:
+//   - error: the classified error with a call stack
+func FromTwirp(code, msg string, meta map[string]string) error {
+	var err error = errors.New(msg)
+
+	if sentinel := errors.SentinelForGRPCCode(grpcCode(code)); sentinel != nil {
+		err = fmt.Errorf("%w: %w", sentinel, err)
+	}
+
+	fields := make(errors.Fields, len(meta)+1)
+	fields["twirp.code"] = code
+
+	for key, value := range meta {
+		fields["twirp.meta."+key] = value
+	}
+
+	return errors.Annotate(errors.WrapSkipping(err, 1, ""), errors.WithFields(fields))
+}
+
+// ToTwirp converts err into a Twirp error carrying the code of CodeOf, the registered (client-safe) message of
+// its definition and the meta of MetaOf, see the package documentation to turn it into a twirp.Error.
+//
+// Parameters:
+//   - err: the error to convert
+//
+// Returns:
+//   - *Error: the Twirp error, or nil if err is nil
+func ToTwirp(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	msg := errors.ErrInternalServerError.Error()
+	if def, ok := errors.DefinitionOf(err); ok && def.Message != "" {
+		msg = def.Message
+	}
+
+	return &Error{err: err, code: CodeOf(err), msg: msg, meta: MetaOf(err)}
+}
+
+// FromTwirpError converts a twirp.Error received by a client, found in the chain of err, into an error classified
+// like FromTwirp does.
+//
+// Parameters:
+//   - err: the error returned by a Twirp client; a twirp.Error or an error wrapping one
+//
+// Returns:
+//   - error: the classified error with a call stack, err unchanged if it holds no Twirp error, or nil if err is nil
+func FromTwirpError(err error) error {
+	twerr, ok := errors.AsType[twirpError](err)
+	if !ok {
+		return err
+	}
+
+	var code string
+
+	// twirp.Error.Code returns a twirp.ErrorCode, a named string type this package cannot import.
+	if method := reflect.ValueOf(twerr).MethodByName("Code"); method.IsValid() && method.Type().NumIn() == 0 &&
+		method.Type().NumOut() == 1 && method.Type().Out(0).Kind() == reflect.String {
+		code = method.Call(nil)[0].String()
+	}
+
+	return FromTwirp(code, twerr.Msg(), twerr.MetaMap())
+}
+
+// Code returns the Twirp error code, the string value of a twirp.ErrorCode.
+//
+// Returns:
+//   - string: the Twirp error code
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Msg returns the client-safe message of the error.
+//
+// Returns:
+//   - string: the message
+func (e *Error) Msg() string {
+	return e.msg
+}
+
+// Meta returns the meta value stored under key.
+//
+// Parameters:
+//   - key: the meta key
+//
+// Returns:
+//   - string: the value, or an empty string if none is set
+func (e *Error) Meta(key string) string {
+	return e.meta[key]
+}
+
+// WithMeta returns a copy of the error with the meta value set, like twirp.Error.WithMeta.
+//
+// Parameters:
+//   - key: the meta key
+//   - value: the meta value
+//
+// Returns:
+//   - *Error: the annotated copy
+func (e *Error) WithMeta(key, value string) *Error {
+	meta := maps.Clone(e.meta)
+	if meta == nil {
+		meta = make(map[string]string, 1)
+	}
+
+	meta[key] = value
+
+	return &Error{err: e.err, code: e.code, msg: e.msg, meta: meta}
+}
+
+// MetaMap returns the meta of the error.
+//
+// Returns:
+//   - map[string]string: a copy of the meta entries
+func (e *Error) MetaMap() map[string]string {
+	return maps.Clone(e.meta)
+}
+
+// Error renders the error like twirp.Error does.
+//
+// Returns:
+//   - string: "twirp error <code>: <msg>"
+func (e *Error) Error() string {
+	return "twirp error " + e.code + ": " + e.msg
+}
+
+// Unwrap returns the converted error, so Is and As see through the Twirp error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// twirpCode translates the name of a gRPC code into the Twirp error code of the same name, e.g. NotFound into
+// not_found.
+func twirpCode(name string) string {
+	var code strings.Builder
+
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			code.WriteByte('_')
+		}
+
+		code.WriteRune(unicode.ToLower(r))
+	}
+
+	return code.String()
+}
+
+// grpcCode translates a Twirp error code into the name of the matching gRPC code, e.g. not_found into NotFound.
+func grpcCode(code string) string {
+	switch code {
+	case Malformed:
+		return "InvalidArgument"
+	case BadRoute:
+		return "NotFound"
+	}
+
+	var name strings.Builder
+
+	for word := range strings.SplitSeq(code, "_") {
+		if word != "" {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return name.String()
+}
@@ -0,0 +1,176 @@
+// Package txerrors runs database/sql transactions with consistent error handling: begin, commit and rollback
+// failures are wrapped distinctly from the error of the transaction body, rollback failures are kept as secondary
+// causes, and serialization failures are classified as retryable:
+//
+//	err := txerrors.Run(ctx, db, func(tx *sql.Tx) error {
+//		_, err := tx.ExecContext(ctx, "UPDATE accounts SET balance = balance - $1 WHERE id = $2", amount, id)
+//		return err
+//	})
+package txerrors
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/ceearrashee/errors"
+)
+
+const (
+	// FieldPhase is the field holding the phase of the transaction that failed: begin, body, commit or rollback.
+	FieldPhase = "tx.phase"
+	// CauseRollback is the name of the secondary cause holding a failed rollback (see errors.NamedCause).
+	CauseRollback = "rollback_error"
+
+	// CodeSerializationFailure is the code of ErrSerializationFailure.
+	CodeSerializationFailure errors.ErrorCode = "serialization_failure"
+
+	phaseBegin    = "begin"
+	phaseBody     = "body"
+	phaseCommit   = "commit"
+	phaseRollback = "rollback"
+)
+
+type (
+	// Beginner starts transactions; it is implemented by *sql.DB and *sql.Conn.
+	Beginner interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	}
+
+	// Option customizes a transaction run by Run.
+	Option func(*config)
+
+	config struct {
+		txOptions    *sql.TxOptions
+		serializable func(err error) bool
+	}
+)
+
+// ErrSerializationFailure classifies transactions aborted because they conflicted with concurrent ones
+// (serialization failures and deadlocks); it is registered as retryable.
+var ErrSerializationFailure = errors.New("transaction serialization failure") //nolint:gochecknoglobals
+
+func init() { //nolint:gochecknoinits
+	errors.RegisterDefinition(errors.ErrorDefinition{
+		Code:       CodeSerializationFailure,
+		Err:        ErrSerializationFailure,
+		HTTPStatus: http.StatusConflict,
+		Retryable:  true,
+	})
+}
+
+// TxOptions sets the isolation level and read-only mode of the transaction.
+//
+// Parameters:
+//   - opts: the options passed to BeginTx
+//
+// Returns:
+//   - Option: the option applying the transaction options
+func TxOptions(opts *sql.TxOptions) Option {
+	return func(c *config) {
+		c.txOptions = opts
+	}
+}
+
+// SerializationFailure replaces the function detecting serialization failures, e.g. to recognize
+// driver-specific error types (IsSerializationFailure by default).
+//
+// Parameters:
+//   - detect: reports whether a driver error is a serialization failure
+//
+// Returns:
+//   - Option: the option applying the detection
+func SerializationFailure(detect func(err error) bool) Option {
+	return func(c *config) {
+		c.serializable = detect
+	}
+}
+
+// IsSerializationFailure reports whether err carries the SQLSTATE of a serialization failure (40001)
+// or a deadlock (40P01), as exposed through a SQLState() method by drivers such as pgx and lib/pq.
+//
+// Parameters:
+//   - err: the driver error to inspect
+//
+// Returns:
+//   - bool: true if err is a serialization failure
+func IsSerializationFailure(err error) bool {
+	stateful, ok := errors.AsType[interface {
+		error
+		SQLState() string
+	}](err)
+	if !ok {
+		return false
+	}
+
+	switch stateful.SQLState() {
+	case "40001", "40P01":
+		return true
+	default:
+		return false
+	}
+}
+
+// Run runs fn in a transaction, committing it when fn succeeds and rolling it back when fn fails or panics.
+// Failures are wrapped with the failing phase in the FieldPhase field; the error of fn is wrapped rather than
+// modified, so sentinels returned by fn keep matching with Is, and carries a failed rollback as the CauseRollback
+// named cause. Serialization failures
+// are classified as ErrSerializationFailure, so errors.IsRetryable reports them as retryable.
+//
+// Parameters:
+//   - ctx: the context of the transaction
+//   - db: the database or connection starting the transaction
+//   - fn: the transaction body
+//   - opts: options customizing the transaction
+//
+// Returns:
+//   - error: nil if the transaction was committed, the classified failure otherwise
+func Run(ctx context.Context, db Beginner, fn func(tx *sql.Tx) error, opts ...Option) error {
+	cfg := config{serializable: IsSerializationFailure}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tx, err := db.BeginTx(ctx, cfg.txOptions)
+	if err != nil {
+		return cfg.wrap(err, phaseBegin, "begin transaction")
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback() //nolint:errcheck
+
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		err = cfg.wrap(err, phaseBody, "run transaction")
+
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = errors.WithNamedCause(err, CauseRollback, cfg.wrap(rollbackErr, phaseRollback, "rollback transaction"))
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return cfg.wrap(err, phaseCommit, "commit transaction")
+	}
+
+	return nil
+}
+
+// classify joins ErrSerializationFailure into serialization failures.
+func (c *config) classify(err error) error {
+	if c.serializable != nil && c.serializable(err) && !errors.Is(err, ErrSerializationFailure) {
+		return errors.Join(ErrSerializationFailure, err)
+	}
+
+	return err
+}
+
+// wrap wraps a transaction failure with its phase, capturing the stack at the caller of Run.
+func (c *config) wrap(err error, phase, description string) error {
+	return errors.Annotate(errors.WrapSkipping(c.classify(err), 2, description), errors.WithField(FieldPhase, phase))
+}
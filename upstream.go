@@ -0,0 +1,42 @@
+package errors
+
+// Upstream identifies the external dependency that produced an error.
+type Upstream struct {
+	// System is the name of the dependency, e.g. "postgres", "stripe" or "billing-service".
+	System string `json:"system"`
+	// Endpoint is the operation or address called on the dependency, e.g. "POST /v1/charges".
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// WithUpstream records which dependency produced the error, so reporters can emit peer.service-style tags
+// and statistics can be grouped by upstream.
+//
+// Parameters:
+//   - system: the name of the dependency
+//   - endpoint: the operation or address called on the dependency; may be empty
+//
+// Returns:
+//   - Option: the option recording the upstream
+func WithUpstream(system, endpoint string) Option {
+	return func(e *Error) {
+		e.upstream = &Upstream{System: system, Endpoint: endpoint}
+	}
+}
+
+// UpstreamOf returns the dependency that produced the error, as recorded by the outermost WithUpstream in the chain.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - Upstream: the recorded upstream
+//   - bool: true if an upstream was recorded
+func UpstreamOf(err error) (Upstream, bool) {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.upstream != nil { //nolint:errorlint
+			return *frameworkErr.upstream, true
+		}
+	}
+
+	return Upstream{}, false
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+type (
+	// ErrorView is a read-only snapshot of an error for untrusted consumers such as plugins and templates.
+	// It exposes what is needed to display the error, scrubbed like reports, but neither the error values
+	// of the chain nor any way to modify them.
+	ErrorView interface {
+		// Message returns the rendered message of the chain.
+		Message() string
+		// Code returns the catalogue code of the error, if classified.
+		Code() ErrorCode
+		// ReferenceCode returns the AutoCode of the error.
+		ReferenceCode() string
+		// Fields returns a copy of the structured fields, with values rendered as strings.
+		Fields() map[string]string
+		// Tags returns a copy of the tags.
+		Tags() []string
+		// Stack returns the rendered call stack, one frame per line.
+		Stack() string
+	}
+
+	errorView struct {
+		message       string
+		code          ErrorCode
+		referenceCode string
+		fields        map[string]string
+		tags          []string
+		stack         string
+	}
+)
+
+// View takes a read-only snapshot of err for displaying it outside of the trusted code.
+//
+// Parameters:
+//   - err: the error to snapshot
+//
+// Returns:
+//   - ErrorView: the snapshot, or nil if err is nil
+func View(err error) ErrorView {
+	if err == nil {
+		return nil
+	}
+
+	view := &errorView{
+		message:       ScrubMessage(err.Error()),
+		code:          CodeOf(err),
+		referenceCode: AutoCode(err),
+		tags:          TagsOf(err),
+	}
+
+	if fields := ScrubFields(FieldsOf(err)); len(fields) > 0 {
+		view.fields = make(map[string]string, len(fields))
+		for key, value := range fields {
+			view.fields[key] = fmt.Sprint(value)
+		}
+	}
+
+	if origin := FindOriginalErrorWithStack(err); origin != nil {
+		view.stack = strings.Join(origin.GetCallStack(), "\n")
+	}
+
+	return view
+}
+
+func (v *errorView) Message() string {
+	return v.message
+}
+
+func (v *errorView) Code() ErrorCode {
+	return v.code
+}
+
+func (v *errorView) ReferenceCode() string {
+	return v.referenceCode
+}
+
+func (v *errorView) Fields() map[string]string {
+	return maps.Clone(v.fields)
+}
+
+func (v *errorView) Tags() []string {
+	return append([]string(nil), v.tags...)
+}
+
+func (v *errorView) Stack() string {
+	return v.stack
+}
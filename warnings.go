@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Warnings is a list of non-fatal warnings, such as those returned by parsers and validation libraries alongside
+	// their result. As an error, it is returned by WithWarnings when the operation succeeded with warnings, so the
+	// result path tells it from a failure with OnlyWarnings:
+	//
+	//	cfg, err := loadConfig(path) // return cfg, errors.WithWarnings(err, parserWarnings...)
+	//	if err != nil && !errors.OnlyWarnings(err) {
+	//		return err
+	//	}
+	//	render(w, cfg, errors.WarningsOf(err).Render())
+	//
+	// It does not unwrap into its members, so warnings never classify the outcome as a failure.
+	Warnings []error
+)
+
+// WithWarnings attaches non-fatal warnings to the outcome of an operation. Reporters emit them at a lower severity
+// than err (see datadog.HandleError), and WarningsOf retrieves them, e.g. to render them in a response, as
+// Partial.WriteHTTP does. A warnings-only outcome is a success for HTTPStatusOf, DefinitionOf and MustClassify.
+//
+// Parameters:
+//   - err: the error of the operation; nil if it succeeded
+//   - warnings: the warnings to attach; nil warnings are ignored
+//
+// Returns:
+//   - error: err unchanged if there is no warning, err wrapped into an *Error carrying the warnings, the warnings
+//     as a Warnings error if err is nil, or nil if both are empty
+func WithWarnings(err error, warnings ...error) error {
+	warnings = slices.DeleteFunc(slices.Clone(warnings), func(warning error) bool { return warning == nil })
+	if len(warnings) == 0 {
+		return err
+	}
+
+	if err == nil {
+		return Warnings(warnings)
+	}
+
+	// A wrapper, unlike a copy, keeps err itself in the chain, so sentinels keep matching with Is.
+	wrapper := &Error{error: err, warnings: warnings}
+	if frameworkErr, ok := err.(*Error); ok { //nolint:errorlint
+		wrapper.stack = frameworkErr.stack
+	}
+
+	return wrapper
+}
+
+// WarningsOf returns the warnings attached to the chain of err with WithWarnings, outermost first.
+//
+// Parameters:
+//   - err: the outcome of the operation
+//
+// Returns:
+//   - Warnings: the attached warnings, or nil if there are none
+func WarningsOf(err error) Warnings {
+	var warnings Warnings
+
+	for e := range Chain(err) {
+		switch x := e.(type) { //nolint:errorlint
+		case *Error:
+			warnings = append(warnings, x.warnings...)
+		case Warnings:
+			warnings = append(warnings, x...)
+		}
+	}
+
+	return warnings
+}
+
+// OnlyWarnings reports whether err holds nothing but warnings, i.e. the operation succeeded with the warnings
+// returned by WithWarnings, possibly wrapped since.
+//
+// Parameters:
+//   - err: the outcome of the operation
+//
+// Returns:
+//   - bool: true if err is a possibly wrapped Warnings, false if it is a failure or nil
+func OnlyWarnings(err error) bool {
+	for err != nil {
+		switch x := err.(type) { //nolint:errorlint
+		case Warnings:
+			return true
+		case *Error:
+			err = x.error
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// Error renders the warnings, e.g. "2 warnings: unknown key \"colour\"; deprecated key \"size\"".
+//
+// Returns:
+//   - string: the rendered warnings
+func (w Warnings) Error() string {
+	messages := make([]string, 0, len(w))
+	for _, warning := range w {
+		messages = append(messages, warning.Error())
+	}
+
+	if len(w) == 1 {
+		return "warning: " + messages[0]
+	}
+
+	return strconv.Itoa(len(w)) + " warnings: " + strings.Join(messages, "; ")
+}
+
+// Render describes the warnings for a response "warnings" extension, like (*Collector).Warnings.
+//
+// Returns:
+//   - []Warning: one entry per warning with its code and client-safe message, or nil if there are none
+func (w Warnings) Render() []Warning {
+	if len(w) == 0 {
+		return nil
+	}
+
+	rendered := make([]Warning, 0, len(w))
+	for _, warning := range w {
+		rendered = append(rendered, Warning{Code: CodeOf(warning), Message: warningMessage(warning)})
+	}
+
+	return rendered
+}
@@ -0,0 +1,79 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestWithWarningsKeepsClassification(t *testing.T) {
+	t.Parallel()
+
+	warning := errors.New("deprecated key \"size\"")
+
+	cases := map[string]struct {
+		err        error
+		sentinel   error
+		wantStatus int
+	}{
+		"sentinel":         {errors.WithWarnings(errors.ErrNotFound, warning), errors.ErrNotFound, http.StatusNotFound},
+		"wrapped sentinel": {errors.WithWarnings(errors.Wrap(errQuota, "charge"), warning), errQuota, http.StatusTooManyRequests},
+		"warnings only":    {errors.WithWarnings(nil, warning), nil, http.StatusOK},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.sentinel != nil && !errors.Is(tc.err, tc.sentinel) {
+				t.Fatalf("Is(%v, %v) = false, want true", tc.err, tc.sentinel)
+			}
+
+			if got := errors.HTTPStatusOf(tc.err); got != tc.wantStatus {
+				t.Fatalf("HTTPStatusOf = %d, want %d", got, tc.wantStatus)
+			}
+
+			if errors.Is(errors.MustClassify(tc.err), errors.ErrInternalServerError) {
+				t.Fatalf("MustClassify(%v) classified it as ErrInternalServerError", tc.err)
+			}
+
+			if len(errors.WarningsOf(tc.err)) != 1 {
+				t.Fatalf("WarningsOf(%v) = %v, want the attached warning", tc.err, errors.WarningsOf(tc.err))
+			}
+		})
+	}
+}
+
+func TestPartialWritesWarnings(t *testing.T) {
+	t.Parallel()
+
+	var result errors.Partial[string]
+
+	result.Add("a")
+	result.Fail(errors.WithWarnings(nil, errors.New("unknown key \"colour\"")))
+
+	recorder := httptest.NewRecorder()
+	if err := result.WriteHTTP(recorder); err != nil {
+		t.Fatalf("WriteHTTP: %v", err)
+	}
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var payload struct {
+		Failed   []errors.PartialFailure `json:"failed"`
+		Warnings []errors.Warning        `json:"warnings"`
+	}
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	if len(payload.Failed) != 0 || len(payload.Warnings) != 1 {
+		t.Fatalf("response = %s, want no failure and one warning", recorder.Body)
+	}
+}
@@ -0,0 +1,208 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WireVersion is the schema version of the JSON envelope written by MarshalJSON.
+// Parsers accept envelopes of any version: fields they do not know are preserved and re-emitted,
+// so services can upgrade the package independently of each other.
+const WireVersion = 1
+
+type (
+	// wireRecord keeps what a parsed error was received with, so it is re-emitted unchanged.
+	wireRecord struct {
+		version     int
+		description string
+		code        string
+		fingerprint string
+		stack       []string
+		extra       map[string]json.RawMessage
+	}
+
+	jsonErrorAlias jsonError
+
+	// WireError is an error decoded by ParseJSON. It renders, formats, serializes and unwraps as the decoded error:
+	// an *Error, or an *Aggregate for an envelope holding several errors.
+	WireError struct {
+		err error
+	}
+)
+
+//nolint:gochecknoglobals
+var jsonErrorKeys = sync.OnceValue(func() map[string]bool {
+	keys := make(map[string]bool)
+	typ := reflect.TypeFor[jsonError]()
+
+	for i := range typ.NumField() {
+		if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); name != "" {
+			keys[name] = true
+		}
+	}
+
+	return keys
+})
+
+// ParseJSON decodes an error serialized by MarshalJSON, possibly by another version of the package.
+// The returned error renders the received message and carries its fields, tags, template, upstream,
+// instance ID and named causes; an envelope holding several errors is decoded into an Aggregate.
+// The reference code, fingerprint, stack and unknown fields are kept and re-emitted when the error
+// (or an error wrapping it) is serialized again.
+//
+// Parameters:
+//   - data: the JSON envelope
+//
+// Returns:
+//   - *WireError: the decoded error, or nil if data is not a valid envelope
+//   - error: an error if data is not a valid envelope
+func ParseJSON(data []byte) (*WireError, error) {
+	var envelope jsonError
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, Wrap(err, "parse error envelope")
+	}
+
+	return &WireError{err: fromJSONError(envelope, envelope.Version)}, nil
+}
+
+// Error returns the message of the decoded error.
+func (e *WireError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the decoded error, so Is and As see through the wire error.
+func (e *WireError) Unwrap() error {
+	return e.err
+}
+
+// Format formats the decoded error, so %+v prints what it was received with.
+func (e *WireError) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), e.err)
+}
+
+// MarshalJSON serializes the decoded error, re-emitting the envelope it was received with.
+//
+// Returns:
+//   - []byte: the JSON encoding of the error
+//   - error: an error if the encoding fails
+func (e *WireError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.err) //nolint:wrapcheck
+}
+
+// WireVersionOf returns the schema version of the envelope the outermost parsed error of the chain was received with.
+//
+// Parameters:
+//   - err: the error chain to search through
+//
+// Returns:
+//   - int: the received version, or zero if the error was not parsed or predates versioning
+func WireVersionOf(err error) int {
+	if record := wireRecordOf(err); record != nil {
+		return record.version
+	}
+
+	return 0
+}
+
+// UnmarshalJSON decodes the envelope, collecting the fields unknown to this version into extra.
+func (j *jsonError) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := json.Unmarshal(data, (*jsonErrorAlias)(j)); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	known := jsonErrorKeys()
+	maps.DeleteFunc(raw, func(key string, _ json.RawMessage) bool { return known[key] })
+
+	if len(raw) > 0 {
+		j.extra = raw
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the envelope, re-emitting the preserved unknown fields.
+func (j jsonError) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(jsonErrorAlias(j))
+	if err != nil || len(j.extra) == 0 {
+		return data, err //nolint:wrapcheck
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	for key, value := range j.extra {
+		if _, ok := merged[key]; !ok {
+			merged[key] = value
+		}
+	}
+
+	return json.Marshal(merged) //nolint:wrapcheck
+}
+
+func fromJSONError(envelope jsonError, version int) error {
+	if len(envelope.Errors) > 0 {
+		members := make([]error, 0, len(envelope.Errors))
+		for _, member := range envelope.Errors {
+			members = append(members, fromJSONError(member, version))
+		}
+
+		return Append(nil, members...)
+	}
+
+	parsed := &Error{
+		Description: envelope.Message,
+		template:    envelope.Template,
+		fields:      envelope.Fields,
+		tags:        envelope.Tags,
+		upstream:    envelope.Upstream,
+		attachments: envelope.Attachments,
+		instanceID:  envelope.InstanceID,
+		wire: &wireRecord{
+			version:     version,
+			description: envelope.Description,
+			code:        envelope.Code,
+			fingerprint: envelope.Fingerprint,
+			stack:       envelope.Stack,
+			extra:       envelope.extra,
+		},
+	}
+
+	if len(envelope.Causes) > 0 {
+		parsed.causes = make(map[string]error, len(envelope.Causes))
+		for name, cause := range envelope.Causes {
+			parsed.causes[name] = fromJSONError(cause, version)
+		}
+	}
+
+	return parsed
+}
+
+func wireRecordOf(err error) *wireRecord {
+	for e := range Chain(err) {
+		if frameworkErr, ok := e.(*Error); ok && frameworkErr.wire != nil { //nolint:errorlint
+			return frameworkErr.wire
+		}
+	}
+
+	return nil
+}
+
+// wireExtra returns the unknown fields of the outermost parsed error of the chain.
+func wireExtra(err error) map[string]json.RawMessage {
+	if record := wireRecordOf(err); record != nil {
+		return record.extra
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ceearrashee/errors"
+)
+
+func TestParseJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]error{
+		"error":     errors.Annotate(errors.NewWithStack("charge failed"), errors.WithField("invoice", "42")),
+		"aggregate": errors.Append(nil, errors.NewWithStack("first"), errors.NewWithStack("second")),
+	}
+
+	for name, original := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(original)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			parsed, err := errors.ParseJSON(data)
+			if err != nil {
+				t.Fatalf("ParseJSON: %v", err)
+			}
+
+			if parsed.Error() != original.Error() {
+				t.Fatalf("parsed message = %q, want %q", parsed.Error(), original.Error())
+			}
+
+			again, err := json.Marshal(parsed)
+			if err != nil {
+				t.Fatalf("Marshal parsed: %v", err)
+			}
+
+			if string(again) != string(data) {
+				t.Fatalf("re-emitted envelope = %s, want %s", again, data)
+			}
+		})
+	}
+}
+
+func TestParseJSONRejectsInvalidEnvelopes(t *testing.T) {
+	t.Parallel()
+
+	if parsed, err := errors.ParseJSON([]byte("not json")); err == nil || parsed != nil {
+		t.Fatalf("ParseJSON = %v, %v, want nil and an error", parsed, err)
+	}
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+const (
+	// FieldItemKey is the field holding the key of the failing item of a map wrapped with WrapAll.
+	FieldItemKey = "item.key"
+	// FieldItemIndex is the field holding the index of the failing item of a slice wrapped with WrapEach.
+	FieldItemIndex = "item.index"
+)
+
+// WrapAll wraps every per-key error of a fan-out call (batch write, multi-get...) with the description
+// and its key in the FieldItemKey field, combining them into an Aggregate ordered by key. Keys of ordered
+// kinds (integers, floats, strings) are compared by value, so int keys sort 1, 2, 10; other keys are
+// compared by their fmt.Sprint form.
+//
+// Parameters:
+//   - errs: the errors by item key; nil values are skipped
+//   - description: the description of each wrapped error
+//
+// Returns:
+//   - error: nil if every error is nil, the single wrapped error if only one failed, or an *Aggregate otherwise
+func WrapAll[K comparable](errs map[K]error, description string) error {
+	type item struct {
+		value K
+		err   error
+	}
+
+	items := make([]item, 0, len(errs))
+
+	for key, err := range errs {
+		if err != nil {
+			items = append(items, item{value: key, err: err})
+		}
+	}
+
+	slices.SortFunc(items, func(a, b item) int { return compareKeys(a.value, b.value) })
+
+	members := make([]error, 0, len(items))
+	for _, it := range items {
+		members = append(members, wrapItem(it.err, description, FieldItemKey, it.value))
+	}
+
+	return Append(nil, members...)
+}
+
+// compareKeys orders two map keys of the same type, by value for ordered kinds and by fmt.Sprint otherwise.
+func compareKeys(a, b any) int {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	switch x.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(x.Float(), y.Float())
+	case reflect.String:
+		return cmp.Compare(x.String(), y.String())
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// WrapEach wraps every per-item error of a fan-out call with the description and its index
+// in the FieldItemIndex field, combining them into an Aggregate in index order.
+//
+// Parameters:
+//   - errs: the errors by item index; nil values are skipped
+//   - description: the description of each wrapped error
+//
+// Returns:
+//   - error: nil if every error is nil, the single wrapped error if only one failed, or an *Aggregate otherwise
+func WrapEach(errs []error, description string) error {
+	members := make([]error, 0, len(errs))
+
+	for i, err := range errs {
+		if err != nil {
+			members = append(members, wrapItem(err, description, FieldItemIndex, i))
+		}
+	}
+
+	return Append(nil, members...)
+}
+
+// wrapItem wraps the error of a single item, capturing the stack at the caller of WrapAll or WrapEach.
+func wrapItem(err error, description, field string, value any) error {
+	return track(&Error{
+		Description: limitDescription(description),
+		stack:       stackFor(err, 1),
+		error:       err,
+		fields:      Fields{field: value},
+	})
+}